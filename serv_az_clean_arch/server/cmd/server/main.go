@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"os"
 	"server/internal/config"
 	"server/internal/domain/models"
 	"server/internal/domain/requests"
@@ -49,23 +51,82 @@ func main() {
 	}()
 
 	sender := email.InitSender(cfg.Email.Email, cfg.Email.Key, cfg.Email.SMTP, cfg.Email.Port)
-	if !db.Migrator().HasTable(&models.Library{}) {
-		err = db.AutoMigrate(&models.Library{})
+	librarySeedsMigrated := !db.Migrator().HasTable(&models.LibrarySeed{})
+	err = db.AutoMigrate(&models.Library{}, &models.TranslationGroup{}, &models.TranslationVariant{}, &models.Dispute{}, &models.Topic{}, &models.Phrase{},
+		&models.Lemma{}, &models.TranslationLink{}, &models.LibraryVersion{}, &models.LibrarySeed{})
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	logger.Info("Migration library OK")
+
+	usersMigrated := !db.Migrator().HasTable(&models.User{})
+	err = db.AutoMigrate(&models.User{}, &models.Deck{}, &models.CustomWord{}, &models.LookupHistory{}, &models.MissingWord{}, &models.Correction{})
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	// Lookup history isn't filtered by found any more, AutoMigrate keeps old indexes.
+	if db.Migrator().HasIndex(&models.LookupHistory{}, "idx_lookup_histories_found") {
+		if err := db.Migrator().DropIndex(&models.LookupHistory{}, "idx_lookup_histories_found"); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	logger.Info("Migration Users OK")
+
+	repoLibrary := repository.NewLibraryRepository(db, logger)
+	repoWords := repository.NewWordsRepository(db, logger)
+	libInteractor := interactor.NewLibraryInteractor(repoLibrary, repoWords,
+		repository.NewBackUpCopyRepo(cfg.Server.LibraryBackupPath, logger), repository.NewTranslationGroupRepository(db, logger),
+		repository.NewTopicRepository(db, logger), repository.NewPhraseRepository(db, logger),
+		repository.NewImageRepository(cfg.Server.ImagesPath, logger), repository.NewLemmaRepository(db, logger))
+	// A library filled before seeds were recorded counts as seeded, seeding it
+	// again would undo the edits made since.
+	if librarySeedsMigrated {
+		count, err := repoLibrary.CountWords()
 		if err != nil {
 			logger.Fatal(err)
 		}
 
-		logger.Info("Migration library OK")
+		if count > 0 {
+			if err := repoLibrary.MarkLibrarySeeded(ctx, &models.LibrarySeed{Words: int(count)}); err != nil {
+				logger.Fatal(err)
+			}
+		}
 	}
 
-	if !db.Migrator().HasTable(&models.User{}) {
-		err = db.AutoMigrate(&models.User{})
+	if _, err := os.Stat(cfg.Server.LibrarySeedPath); errors.Is(err, os.ErrNotExist) {
+		logger.Infof("There is no library seed at %s, seed skipped", cfg.Server.LibrarySeedPath)
+	} else {
+		seeded, err := libInteractor.SeedLibrary(ctx, cfg.Server.LibrarySeedPath)
 		if err != nil {
 			logger.Fatal(err)
 		}
 
+		if seeded > 0 {
+			logger.Infof("Library seeded from %s, [%v] words", cfg.Server.LibrarySeedPath, seeded)
+		} else {
+			logger.Info("Library is already seeded, seed skipped")
+		}
+	}
+
+	topicsCreated, err := libInteractor.SyncTopics(ctx)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	logger.Infof("Topics synced, [%v] created", topicsCreated)
+
+	lemmasCreated, err := libInteractor.SyncLemmas(ctx)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	logger.Infof("Lemmas synced, [%v] created", lemmasCreated)
+
+	if usersMigrated {
 		repoUser := repository.NewUserRepository(db, logger)
-		repoWords := repository.NewWordsRepository(db, logger)
 		usInteractor := interactor.NewUserInteractor(repoUser, repoWords, sender)
 		adminUserReq := requests.CreateUserRequest{
 			Email:    "admin@admin.admin",
@@ -80,11 +141,9 @@ func main() {
 			logger.Fatal(err)
 		}
 
-		logger.Info("Migration Users OK")
 		logger.Info("Admin created")
 	}
 
-	repoLibrary := repository.NewLibraryRepository(db, logger)
 	err = repoLibrary.InitWordsMap()
 	if err != nil {
 		logger.Fatal(err)
@@ -101,7 +160,11 @@ func main() {
 	e := echo.New()
 	e.Validator = validator.NewValidator(logger)
 
-	e = router.NewRouter(e, r.NewAppController(), cfg.Server.SecretKey, tmpls)
+	e, err = router.NewRouter(e, r.NewAppController(), cfg.Server.SecretKey, cfg.Server.ImagesPath, tmpls)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
 	logger.Infof("Server listen at http://%s:%s", cfg.Server.Host, cfg.Server.AppPort)
 	if err := e.Start(":" + cfg.Server.AppPort); err != nil {
 		logger.Fatalln(err)
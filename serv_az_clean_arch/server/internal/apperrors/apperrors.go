@@ -52,14 +52,26 @@ var (
 		Message: "Failed to GetAllFromBackUp",
 		Code:    mapers,
 	}
+	MapPathToXLSErr = AppError{
+		Message: "Failed to MapPathToXLSErr",
+		Code:    mapers,
+	}
 	ValidateErr = AppError{
 		Message: "Failed to ValidateErr",
 		Code:    mapers,
 	}
+	ValidateLibraryErr = AppError{
+		Message: "Failed to ValidateLibraryErr",
+		Code:    mapers,
+	}
 	InitializeTemplatesErr = AppError{
 		Message: "Failed to InitializeTemplatesErr",
 		Code:    server,
 	}
+	OpenAPIRoutesErr = AppError{
+		Message: "Failed to OpenAPIRoutesErr",
+		Code:    server,
+	}
 	GetAllWordsFromBackUpXlsxErr = AppError{
 		Message: "Failed to GetAllFromBackUp",
 		Code:    backUpRepo,
@@ -88,16 +100,8 @@ var (
 		Message: "Failed to UpdateWordRowAffectedErr",
 		Code:    repoLibrary,
 	}
-	GetTranslationEnglLikeErr = AppError{
-		Message: "Failed to GetTranslationEnglLikeErr",
-		Code:    repoLibrary,
-	}
-	GetTranslationEnglErr = AppError{
-		Message: "Failed to GetTranslationEnglErr",
-		Code:    repoLibrary,
-	}
-	GetTranslationRusLikeErr = AppError{
-		Message: "Failed to GetTranslationRusLikeErr",
+	GetWordsByIDsErr = AppError{
+		Message: "Failed to GetWordsByIDsErr",
 		Code:    repoLibrary,
 	}
 	InitWordsMapErr = AppError{
@@ -108,15 +112,268 @@ var (
 		Message: "Failed to UpdateWordsMapErr",
 		Code:    repoLibrary,
 	}
+	CountWordsErr = AppError{
+		Message: "Failed to CountWordsErr",
+		Code:    repoLibrary,
+	}
+	LibrarySeedErr = AppError{
+		Message: "Failed to LibrarySeedErr",
+		Code:    repoLibrary,
+	}
+	GetWordsByFilterErr = AppError{
+		Message: "Failed to GetWordsByFilterErr",
+		Code:    repoLibrary,
+	}
+	GetWordByIDErr = AppError{
+		Message:  "Failed to GetWordByIDErr",
+		Code:     repoLibrary,
+		HTTPCode: http.StatusNotFound,
+	}
+	GetMaxIDErr = AppError{
+		Message: "Failed to GetMaxIDErr",
+		Code:    repoLibrary,
+	}
+	DeleteWordErr = AppError{
+		Message: "Failed to DeleteWordErr",
+		Code:    repoLibrary,
+	}
+	MergeWordsErr = AppError{
+		Message: "Failed to MergeWordsErr",
+		Code:    repoLibrary,
+	}
+	GetAllGroupsErr = AppError{
+		Message: "Failed to GetAllGroupsErr",
+		Code:    repoGroups,
+	}
+	GetGroupErr = AppError{
+		Message:  "Failed to GetGroupErr",
+		Code:     repoGroups,
+		HTTPCode: http.StatusNotFound,
+	}
+	CreateGroupErr = AppError{
+		Message: "Failed to CreateGroupErr",
+		Code:    repoGroups,
+	}
+	DeleteGroupErr = AppError{
+		Message: "Failed to DeleteGroupErr",
+		Code:    repoGroups,
+	}
+	SetWordGroupErr = AppError{
+		Message: "Failed to SetWordGroupErr",
+		Code:    repoGroups,
+	}
+	AddVariantErr = AppError{
+		Message: "Failed to AddVariantErr",
+		Code:    repoGroups,
+	}
+	DeleteVariantErr = AppError{
+		Message: "Failed to DeleteVariantErr",
+		Code:    repoGroups,
+	}
+	CreateDeckErr = AppError{
+		Message: "Failed to CreateDeckErr",
+		Code:    repoDecks,
+	}
+	GetDecksErr = AppError{
+		Message: "Failed to GetDecksErr",
+		Code:    repoDecks,
+	}
+	GetDeckErr = AppError{
+		Message:  "Failed to GetDeckErr",
+		Code:     repoDecks,
+		HTTPCode: http.StatusNotFound,
+	}
+	GetDeckWordsErr = AppError{
+		Message: "Failed to GetDeckWordsErr",
+		Code:    repoDecks,
+	}
+	LoadDeckWordsErr = AppError{
+		Message: "Failed to LoadDeckWordsErr",
+		Code:    repoDecks,
+	}
+	DeleteDeckErr = AppError{
+		Message: "Failed to DeleteDeckErr",
+		Code:    repoDecks,
+	}
+	AddCustomWordErr = AppError{
+		Message: "Failed to AddCustomWordErr",
+		Code:    repoDecks,
+	}
+	DeleteCustomWordErr = AppError{
+		Message:  "Failed to DeleteCustomWordErr",
+		Code:     repoDecks,
+		HTTPCode: http.StatusNotFound,
+	}
+	GetCustomWordsErr = AppError{
+		Message: "Failed to GetCustomWordsErr",
+		Code:    repoDecks,
+	}
+	UpdateCustomWordErr = AppError{
+		Message: "Failed to UpdateCustomWordErr",
+		Code:    repoDecks,
+	}
+	GetLearnedWordIDsErr = AppError{
+		Message: "Failed to GetLearnedWordIDsErr",
+		Code:    repoUsers,
+	}
+	CreateDisputeErr = AppError{
+		Message: "Failed to CreateDisputeErr",
+		Code:    repoDispute,
+	}
+	GetDisputesErr = AppError{
+		Message: "Failed to GetDisputesErr",
+		Code:    repoDispute,
+	}
+	GetDisputeByIDErr = AppError{
+		Message:  "Failed to GetDisputeByIDErr",
+		Code:     repoDispute,
+		HTTPCode: http.StatusNotFound,
+	}
+	CreateCorrectionErr = AppError{
+		Message: "Failed to CreateCorrectionErr",
+		Code:    repoCorrect,
+	}
+	GetCorrectionsErr = AppError{
+		Message: "Failed to GetCorrectionsErr",
+		Code:    repoCorrect,
+	}
+	GetCorrectionByIDErr = AppError{
+		Message:  "Failed to GetCorrectionByIDErr",
+		Code:     repoCorrect,
+		HTTPCode: http.StatusNotFound,
+	}
+	UpdateCorrectionErr = AppError{
+		Message: "Failed to UpdateCorrectionErr",
+		Code:    repoCorrect,
+	}
+	UpdateDisputeErr = AppError{
+		Message: "Failed to UpdateDisputeErr",
+		Code:    repoDispute,
+	}
+	GetTopicsErr = AppError{
+		Message: "Failed to GetTopicsErr",
+		Code:    repoTopics,
+	}
+	GetTopicErr = AppError{
+		Message:  "Failed to GetTopicErr",
+		Code:     repoTopics,
+		HTTPCode: http.StatusNotFound,
+	}
+	CreateTopicErr = AppError{
+		Message: "Failed to CreateTopicErr",
+		Code:    repoTopics,
+	}
+	UpdateTopicErr = AppError{
+		Message: "Failed to UpdateTopicErr",
+		Code:    repoTopics,
+	}
+	DeleteTopicErr = AppError{
+		Message: "Failed to DeleteTopicErr",
+		Code:    repoTopics,
+	}
+	SetThemeTopicErr = AppError{
+		Message: "Failed to SetThemeTopicErr",
+		Code:    repoTopics,
+	}
+	CountTopicWordsErr = AppError{
+		Message: "Failed to CountTopicWordsErr",
+		Code:    repoTopics,
+	}
+	GetPhrasesErr = AppError{
+		Message: "Failed to GetPhrasesErr",
+		Code:    repoPhrases,
+	}
+	UpsertPhraseErr = AppError{
+		Message: "Failed to UpsertPhraseErr",
+		Code:    repoPhrases,
+	}
+	SyncLemmasErr = AppError{
+		Message: "Failed to SyncLemmasErr",
+		Code:    repoLemmas,
+	}
+	UpdateLemmasMapErr = AppError{
+		Message: "Failed to UpdateLemmasMapErr",
+		Code:    repoLemmas,
+	}
+	GetTranslationsErr = AppError{
+		Message: "Failed to GetTranslationsErr",
+		Code:    repoLemmas,
+	}
+	GetLemmasErr = AppError{
+		Message: "Failed to GetLemmasErr",
+		Code:    repoLemmas,
+	}
+	AddLemmaErr = AppError{
+		Message: "Failed to AddLemmaErr",
+		Code:    repoLemmas,
+	}
+	DeleteLemmaErr = AppError{
+		Message: "Failed to DeleteLemmaErr",
+		Code:    repoLemmas,
+	}
+	UpdateUserLanguagesErr = AppError{
+		Message: "Failed to UpdateUserLanguagesErr",
+		Code:    repoUsers,
+	}
+	UpdateUserKeepHistoryErr = AppError{
+		Message: "Failed to UpdateUserKeepHistoryErr",
+		Code:    repoUsers,
+	}
+	AddLookupErr = AppError{
+		Message: "Failed to AddLookupErr",
+		Code:    repoHistory,
+	}
+	GetUserHistoryErr = AppError{
+		Message: "Failed to GetUserHistoryErr",
+		Code:    repoHistory,
+	}
+	DeleteUserHistoryErr = AppError{
+		Message: "Failed to DeleteUserHistoryErr",
+		Code:    repoHistory,
+	}
+	CountMissingWordErr = AppError{
+		Message: "Failed to CountMissingWordErr",
+		Code:    repoMissing,
+	}
+	GetMissingWordsErr = AppError{
+		Message: "Failed to GetMissingWordsErr",
+		Code:    repoMissing,
+	}
+	GetMissingWordErr = AppError{
+		Message:  "Failed to GetMissingWordErr",
+		Code:     repoMissing,
+		HTTPCode: http.StatusNotFound,
+	}
+	DeleteMissingWordErr = AppError{
+		Message: "Failed to DeleteMissingWordErr",
+		Code:    repoMissing,
+	}
+	PublishMissingWordErr = AppError{
+		Message:  "Failed to PublishMissingWordErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	SaveImageErr = AppError{
+		Message: "Failed to SaveImageErr",
+		Code:    repoImages,
+	}
+	SetWordImageErr = AppError{
+		Message: "Failed to SetWordImageErr",
+		Code:    repoLibrary,
+	}
+	GetWordsWithImageErr = AppError{
+		Message: "Failed to GetWordsWithImageErr",
+		Code:    repoUsers,
+	}
+	GetAllPartsOfSpeechErr = AppError{
+		Message: "Failed to GetAllPartsOfSpeechErr",
+		Code:    repoLibrary,
+	}
 	JWTMiddleware = AppError{
 		Message:  "Failed to JWTMiddlewareErr",
 		Code:     middleware,
 		HTTPCode: http.StatusUnauthorized,
 	}
-	GetTranslationRusErr = AppError{
-		Message: "Failed to GetTranslationRusErr",
-		Code:    repoLibrary,
-	}
 	GetAllWordsLibErr = AppError{
 		Message: "Failed to GetAllWords",
 		Code:    repoLibrary,
@@ -141,6 +398,10 @@ var (
 		Message: "Failed to UpdateUserByIdErr",
 		Code:    repoUsers,
 	}
+	UpdateUserLevelErr = AppError{
+		Message: "Failed to UpdateUserLevelErr",
+		Code:    repoUsers,
+	}
 	GetWordsByUserIdAndLimitAndTopicErr = AppError{
 		Message: "Failed to GetWordsByUserIdAndLimitAndTopicErr",
 		Code:    repoUsers,
@@ -221,6 +482,10 @@ var (
 		Message: "Failed to UpdateUserHandlerErr",
 		Code:    handlers,
 	}
+	UpdateUserLevelHandlerErr = AppError{
+		Message: "Failed to UpdateUserLevelHandlerErr",
+		Code:    handlers,
+	}
 	LoginHandlerErr = AppError{
 		Message: "Failed to LoginHandlerErr",
 		Code:    handlers,
@@ -241,6 +506,89 @@ var (
 		Message: "Failed to CreateUserHandlerErr",
 		Code:    handlers,
 	}
+	AdminLibraryHandlerErr = AppError{
+		Message: "Failed to AdminLibraryHandlerErr",
+		Code:    handlers,
+	}
+	AdminDuplicatesHandlerErr = AppError{
+		Message: "Failed to AdminDuplicatesHandlerErr",
+		Code:    handlers,
+	}
+	AdminTranslationGroupsHandlerErr = AppError{
+		Message: "Failed to AdminTranslationGroupsHandlerErr",
+		Code:    handlers,
+	}
+	TextAnalyzerHandlerErr = AppError{
+		Message: "Failed to TextAnalyzerHandlerErr",
+		Code:    handlers,
+	}
+	DecksHandlerErr = AppError{
+		Message: "Failed to DecksHandlerErr",
+		Code:    handlers,
+	}
+	DisputeHandlerErr = AppError{
+		Message: "Failed to DisputeHandlerErr",
+		Code:    handlers,
+	}
+	CorrectionHandlerErr = AppError{
+		Message: "Failed to CorrectionHandlerErr",
+		Code:    handlers,
+	}
+	APIHandlerErr = AppError{
+		Message: "Failed to APIHandlerErr",
+		Code:    handlers,
+	}
+	APIDocsHandlerErr = AppError{
+		Message: "Failed to APIDocsHandlerErr",
+		Code:    handlers,
+	}
+	APIRequestErr = AppError{
+		Message:  "Failed to APIRequestErr",
+		Code:     handlers,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ClozeHandlerErr = AppError{
+		Message: "Failed to ClozeHandlerErr",
+		Code:    handlers,
+	}
+	PictureTestHandlerErr = AppError{
+		Message: "Failed to PictureTestHandlerErr",
+		Code:    handlers,
+	}
+	UpdateUserLanguagesHandlerErr = AppError{
+		Message: "Failed to UpdateUserLanguagesHandlerErr",
+		Code:    handlers,
+	}
+	HistoryHandlerErr = AppError{
+		Message: "Failed to HistoryHandlerErr",
+		Code:    handlers,
+	}
+	StudyWordHandlerErr = AppError{
+		Message: "Failed to StudyWordHandlerErr",
+		Code:    handlers,
+	}
+	AdminMissingWordsHandlerErr = AppError{
+		Message: "Failed to AdminMissingWordsHandlerErr",
+		Code:    handlers,
+	}
+	AdminLemmasHandlerErr = AppError{
+		Message: "Failed to AdminLemmasHandlerErr",
+		Code:    handlers,
+	}
+	AdminImagesHandlerErr = AppError{
+		Message:  "Failed to AdminImagesHandlerErr",
+		Code:     handlers,
+		HTTPCode: http.StatusBadRequest,
+	}
+	AdminTopicsHandlerErr = AppError{
+		Message: "Failed to AdminTopicsHandlerErr",
+		Code:    handlers,
+	}
+	AdminAccessErr = AppError{
+		Message:  "Failed to AdminAccessErr",
+		Code:     handlers,
+		HTTPCode: http.StatusForbidden,
+	}
 	LearnHandlerErr = AppError{
 		Message: "Failed to LearnHandlerErr",
 		Code:    handlers,
@@ -262,10 +610,6 @@ var (
 		Message: "Failed to GetLearnByUsIdAndLimitErr",
 		Code:    services,
 	}
-	GetTranslationByWordErr = AppError{
-		Message: "Failed to GetTranslationByWordErr",
-		Code:    services,
-	}
 	SignInUserWithJWTErr = AppError{
 		Message:  "Failed to SignInUserWithJWTErr",
 		Code:     services,
@@ -295,6 +639,134 @@ var (
 		Message: "Failed to AddWordsToUserErr",
 		Code:    services,
 	}
+	CreateLibraryWordErr = AppError{
+		Message:  "Failed to CreateLibraryWordErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	UpdateLibraryWordErr = AppError{
+		Message:  "Failed to UpdateLibraryWordErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	DeleteLibraryWordErr = AppError{
+		Message:  "Failed to DeleteLibraryWordErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	MergeDuplicatesErr = AppError{
+		Message:  "Failed to MergeDuplicatesErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	TranslationGroupErr = AppError{
+		Message:  "Failed to TranslationGroupErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	AnalyzeTextErr = AppError{
+		Message:  "Failed to AnalyzeTextErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	CreateDeckFromTextErr = AppError{
+		Message:  "Failed to CreateDeckFromTextErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ImportDeckErr = AppError{
+		Message:  "Failed to ImportDeckErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	DeckErr = AppError{
+		Message:  "Failed to DeckErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	CustomWordErr = AppError{
+		Message:  "Failed to CustomWordErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	FileDisputeErr = AppError{
+		Message:  "Failed to FileDisputeErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ResolveDisputeErr = AppError{
+		Message:  "Failed to ResolveDisputeErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	SuggestCorrectionErr = AppError{
+		Message:  "Failed to SuggestCorrectionErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ResolveCorrectionErr = AppError{
+		Message:  "Failed to ResolveCorrectionErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	TestSessionErr = AppError{
+		Message:  "Failed to TestSessionErr",
+		Code:     services,
+		HTTPCode: http.StatusConflict,
+	}
+	StaleCorrectionErr = AppError{
+		Message:  "Failed to StaleCorrectionErr",
+		Code:     services,
+		HTTPCode: http.StatusConflict,
+	}
+	CourseLevelErr = AppError{
+		Message:  "Failed to CourseLevelErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	TopicErr = AppError{
+		Message:  "Failed to TopicErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ImportPhrasesErr = AppError{
+		Message:  "Failed to ImportPhrasesErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	UnsupportedLanguageErr = AppError{
+		Message:  "Failed to UnsupportedLanguageErr",
+		Code:     language,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	LemmaErr = AppError{
+		Message:  "Failed to LemmaErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	LanguagePairErr = AppError{
+		Message:  "Failed to LanguagePairErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ImageErr = AppError{
+		Message:  "Failed to ImageErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	ImportImagesErr = AppError{
+		Message:  "Failed to ImportImagesErr",
+		Code:     services,
+		HTTPCode: http.StatusBadRequest,
+	}
+	SyncTopicsErr = AppError{
+		Message: "Failed to SyncTopicsErr",
+		Code:    services,
+	}
+	SeedLibraryErr = AppError{
+		Message: "Failed to SeedLibraryErr",
+		Code:    services,
+	}
 	GetAllTopicsLibServErr = AppError{
 		Message: "Failed to GetAllTopicsLibServErr",
 		Code:    services,
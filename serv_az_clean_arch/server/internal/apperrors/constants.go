@@ -9,9 +9,20 @@ const (
 	backUpRepo  = "BACKUP_REPO_ERR"
 	repoLibrary = "REPO_LIBRARY_ERR"
 	repoUsers   = "REPO_USERS_ERR"
+	repoGroups  = "REPO_GROUPS_ERR"
+	repoDispute = "REPO_DISPUTES_ERR"
+	repoTopics  = "REPO_TOPICS_ERR"
+	repoPhrases = "REPO_PHRASES_ERR"
+	repoImages  = "REPO_IMAGES_ERR"
+	repoLemmas  = "REPO_LEMMAS_ERR"
+	repoDecks   = "REPO_DECKS_ERR"
+	repoHistory = "REPO_HISTORY_ERR"
+	repoMissing = "REPO_MISSING_WORDS_ERR"
+	repoCorrect = "REPO_CORRECTIONS_ERR"
 	handlers    = "HANDLERS_ERR"
 	services    = "SERVICES_ERR"
 	mapers      = "MAPPERS_ERR"
+	language    = "LANGUAGE_ERR"
 	server      = "SERVER_ERR"
 	email       = "EMAIL_Err"
 )
@@ -10,6 +10,15 @@ import (
 
 var path = ".env"
 
+const (
+	defaultLibrarySeedPath      = "save_copy/library.xlsx"
+	defaultLibraryBackupPath    = "save_copy/library_backup.xlsx"
+	defaultImagesPath           = "save_copy/images"
+	defaultQuickAnswerLimit     = 8
+	defaultQuickAnswerMinPrefix = 2
+	defaultDeckLearnLimit       = 50
+)
+
 type Config struct {
 	AppPort  string `required:"true" split_words:"true"`
 	Postgres *PostgresConfig
@@ -36,6 +45,12 @@ type ServerConfig struct {
 	Host                   string `env:"HOST"`
 	ExpirationJWTInSeconds string `env:"EXPIRATION_JWT_SECONDS"`
 	TimeoutContext         string `env:"TIMEOUT_CONTEXT"`
+	LibrarySeedPath        string `env:"LIBRARY_SEED_PATH"`
+	LibraryBackupPath      string `env:"LIBRARY_BACKUP_PATH"`
+	ImagesPath             string `env:"IMAGES_PATH"`
+	QuickAnswerLimit       int    `env:"QUICK_ANSWER_LIMIT"`
+	QuickAnswerMinPrefix   int    `env:"QUICK_ANSWER_MIN_PREFIX"`
+	DeckLearnLimit         int    `env:"DECK_LEARN_LIMIT"`
 }
 
 type EmailConfig struct {
@@ -64,6 +79,30 @@ func NewConfig(logger *logrus.Logger) (*Config, error) {
 		return nil, appErr
 	}
 
+	if confServer.LibrarySeedPath == "" {
+		confServer.LibrarySeedPath = defaultLibrarySeedPath
+	}
+
+	if confServer.LibraryBackupPath == "" {
+		confServer.LibraryBackupPath = defaultLibraryBackupPath
+	}
+
+	if confServer.ImagesPath == "" {
+		confServer.ImagesPath = defaultImagesPath
+	}
+
+	if confServer.QuickAnswerLimit <= 0 {
+		confServer.QuickAnswerLimit = defaultQuickAnswerLimit
+	}
+
+	if confServer.QuickAnswerMinPrefix <= 0 {
+		confServer.QuickAnswerMinPrefix = defaultQuickAnswerMinPrefix
+	}
+
+	if confServer.DeckLearnLimit <= 0 {
+		confServer.DeckLearnLimit = defaultDeckLearnLimit
+	}
+
 	confEmail := &EmailConfig{}
 	if err := env.Parse(confEmail); err != nil {
 		appErr := apperrors.EnvConfigParseError.AppendMessage(err)
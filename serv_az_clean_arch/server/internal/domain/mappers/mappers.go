@@ -11,6 +11,7 @@ import (
 	"server/internal/domain/requests"
 	"server/internal/domain/responses"
 	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/google/uuid"
@@ -45,11 +46,18 @@ func MapLibraryToWords(library []*models.Library) []*models.Word {
 	words := []*models.Word{}
 	for _, libWord := range library {
 		tempWord := &models.Word{
-			ID:            libWord.ID,
-			Russian:       libWord.Russian,
-			English:       libWord.English,
-			Theme:         libWord.Theme,
-			PartsOfSpeech: libWord.PartsOfSpeech,
+			ID:              libWord.ID,
+			Russian:         libWord.Russian,
+			English:         libWord.English,
+			Theme:           libWord.Theme,
+			PartsOfSpeech:   libWord.PartsOfSpeech,
+			Level:           libWord.Level,
+			Frequency:       libWord.Frequency,
+			Transcription:   libWord.Transcription,
+			RussianStressed: libWord.RussianStressed,
+			Forms:           libWord.Forms,
+			Register:        libWord.Register,
+			UsageNote:       libWord.UsageNote,
 		}
 
 		words = append(words, tempWord)
@@ -58,12 +66,102 @@ func MapLibraryToWords(library []*models.Library) []*models.Word {
 	return words
 }
 
+func MapLibraryToWord(libWord *models.Library) *models.Word {
+	return &models.Word{
+		ID:              libWord.ID,
+		Russian:         libWord.Russian,
+		English:         libWord.English,
+		Preposition:     libWord.Preposition,
+		Theme:           libWord.Theme,
+		PartsOfSpeech:   libWord.PartsOfSpeech,
+		Level:           libWord.Level,
+		Frequency:       libWord.Frequency,
+		Transcription:   libWord.Transcription,
+		RussianStressed: libWord.RussianStressed,
+		Forms:           libWord.Forms,
+		Register:        libWord.Register,
+		UsageNote:       libWord.UsageNote,
+	}
+}
+
+// MapLibraryToLibraryWordRequest fills the library form with the word, so an
+// update changes only what the form changes.
+func MapLibraryToLibraryWordRequest(word *models.Library) *requests.LibraryWordRequest {
+	frequency := ""
+	if word.Frequency > 0 {
+		frequency = strconv.Itoa(word.Frequency)
+	}
+
+	return &requests.LibraryWordRequest{
+		ID:               strconv.Itoa(word.ID),
+		English:          word.English,
+		Russian:          word.Russian,
+		Preposition:      word.Preposition,
+		Theme:            word.Theme,
+		PartsOfSpeech:    word.PartsOfSpeech,
+		Root:             word.Root,
+		TranslationGroup: word.TranslationGroupName,
+		Level:            word.Level,
+		Frequency:        frequency,
+		Transcription:    word.Transcription,
+		RussianStressed:  word.RussianStressed,
+		Forms:            word.Forms,
+		Register:         word.Register,
+		UsageNote:        word.UsageNote,
+	}
+}
+
+func MapLibraryWordRequestToLibrary(req *requests.LibraryWordRequest) (*models.Library, error) {
+	id := 0
+	if req.ID != "" {
+		num, err := strconv.Atoi(req.ID)
+		if err != nil {
+			return nil, apperrors.ValidateLibraryErr.AppendMessage(err)
+		}
+
+		id = num
+	}
+
+	frequency := 0
+	if strings.TrimSpace(req.Frequency) != "" {
+		num, err := strconv.Atoi(strings.TrimSpace(req.Frequency))
+		if err != nil {
+			return nil, apperrors.ValidateLibraryErr.AppendMessage(err)
+		}
+
+		frequency = num
+	}
+
+	return &models.Library{
+		ID:                   id,
+		Root:                 capitalizeFirstRune(strings.TrimSpace(req.Root)),
+		English:              capitalizeFirstRune(strings.TrimSpace(req.English)),
+		Preposition:          strings.TrimSpace(req.Preposition),
+		Russian:              capitalizeFirstRune(strings.TrimSpace(req.Russian)),
+		Theme:                strings.TrimSpace(req.Theme),
+		PartsOfSpeech:        strings.TrimSpace(req.PartsOfSpeech),
+		TranslationGroupName: strings.TrimSpace(req.TranslationGroup),
+		Level:                strings.ToUpper(strings.TrimSpace(req.Level)),
+		Frequency:            frequency,
+		Transcription:        strings.TrimSpace(req.Transcription),
+		RussianStressed:      strings.TrimSpace(req.RussianStressed),
+		Forms:                strings.TrimSpace(req.Forms),
+		Register:             strings.ToLower(strings.TrimSpace(req.Register)),
+		UsageNote:            strings.TrimSpace(req.UsageNote),
+	}, nil
+}
+
 func MapLibraryToWordsGetTranslResponse(library []*models.Library) []*responses.GetTranslResponse {
 	words := []*responses.GetTranslResponse{}
 	for _, libWord := range library {
 		tempWord := &responses.GetTranslResponse{
-			Russian: libWord.Russian,
-			English: libWord.English,
+			Russian:         libWord.Russian,
+			English:         libWord.English,
+			Transcription:   libWord.Transcription,
+			RussianStressed: libWord.RussianStressed,
+			Forms:           libWord.Forms,
+			Register:        libWord.Register,
+			UsageNote:       libWord.UsageNote,
 		}
 
 		words = append(words, tempWord)
@@ -120,6 +218,16 @@ func MapMultipartToXLS(file *multipart.File) (*xlsx.File, error) {
 	return xlFile, nil
 }
 
+func MapPathToXLS(path string) (*xlsx.File, error) {
+	xlFile, err := xlsx.OpenFile(path)
+	if err != nil {
+		appErr := apperrors.MapPathToXLSErr.AppendMessage(err)
+		return nil, appErr
+	}
+
+	return xlFile, nil
+}
+
 func MapXLStoLibrary(xlFile *xlsx.File) []*models.Library {
 	wordNew := []*models.Library{}
 	for _, sheet := range xlFile.Sheets {
@@ -127,6 +235,10 @@ func MapXLStoLibrary(xlFile *xlsx.File) []*models.Library {
 			break
 		}
 
+		if models.IsExtraSheet(sheet.Name) {
+			continue
+		}
+
 		for _, row := range sheet.Rows {
 			if len(row.Cells) == 0 {
 				continue
@@ -138,13 +250,21 @@ func MapXLStoLibrary(xlFile *xlsx.File) []*models.Library {
 			}
 
 			word := &models.Library{
-				ID:            num,
-				Root:          capitalizeFirstRune(row.Cells[1].String()),
-				English:       capitalizeFirstRune(row.Cells[2].String()),
-				Preposition:   row.Cells[3].String(),
-				Russian:       capitalizeFirstRune(row.Cells[4].String()),
-				Theme:         row.Cells[5].String(),
-				PartsOfSpeech: row.Cells[6].String(),
+				ID:                   num,
+				Root:                 capitalizeFirstRune(cellValue(row, 1)),
+				English:              capitalizeFirstRune(cellValue(row, 2)),
+				Preposition:          cellValue(row, 3),
+				Russian:              capitalizeFirstRune(cellValue(row, 4)),
+				Theme:                cellValue(row, 5),
+				PartsOfSpeech:        cellValue(row, 6),
+				TranslationGroupName: strings.TrimSpace(cellValue(row, 7)),
+				Level:                strings.ToUpper(strings.TrimSpace(cellValue(row, 8))),
+				Frequency:            cellInt(row, 9),
+				Transcription:        strings.TrimSpace(cellValue(row, 10)),
+				RussianStressed:      capitalizeFirstRune(strings.TrimSpace(cellValue(row, 11))),
+				Forms:                strings.TrimSpace(cellValue(row, 12)),
+				Register:             strings.ToLower(strings.TrimSpace(cellValue(row, 13))),
+				UsageNote:            strings.TrimSpace(cellValue(row, 14)),
 			}
 
 			wordNew = append(wordNew, word)
@@ -161,6 +281,10 @@ func MapXLStoWords(xlFile *xlsx.File) []*models.Word {
 			break
 		}
 
+		if models.IsExtraSheet(sheet.Name) {
+			continue
+		}
+
 		for _, row := range sheet.Rows {
 			if len(row.Cells) == 0 {
 				continue
@@ -173,12 +297,19 @@ func MapXLStoWords(xlFile *xlsx.File) []*models.Word {
 
 			word := &models.Word{
 				ID: num,
-				//Root:          capitalizeFirstRune(row.Cells[1].String()),
-				English:       capitalizeFirstRune(row.Cells[2].String()),
-				Preposition:   row.Cells[3].String(),
-				Russian:       capitalizeFirstRune(row.Cells[4].String()),
-				Theme:         row.Cells[5].String(),
-				PartsOfSpeech: row.Cells[6].String(),
+				//Root:          capitalizeFirstRune(cellValue(row, 1)),
+				English:         capitalizeFirstRune(cellValue(row, 2)),
+				Preposition:     cellValue(row, 3),
+				Russian:         capitalizeFirstRune(cellValue(row, 4)),
+				Theme:           cellValue(row, 5),
+				PartsOfSpeech:   cellValue(row, 6),
+				Level:           strings.ToUpper(strings.TrimSpace(cellValue(row, 8))),
+				Frequency:       cellInt(row, 9),
+				Transcription:   strings.TrimSpace(cellValue(row, 10)),
+				RussianStressed: capitalizeFirstRune(strings.TrimSpace(cellValue(row, 11))),
+				Forms:           strings.TrimSpace(cellValue(row, 12)),
+				Register:        strings.ToLower(strings.TrimSpace(cellValue(row, 13))),
+				UsageNote:       strings.TrimSpace(cellValue(row, 14)),
 			}
 
 			wordNew = append(wordNew, word)
@@ -188,6 +319,131 @@ func MapXLStoWords(xlFile *xlsx.File) []*models.Word {
 	return wordNew
 }
 
+// MapXLStoPhrases reads the sentences sheet. Phrases without word IDs get
+// no Libraries and are linked by the caller.
+func MapXLStoPhrases(xlFile *xlsx.File) ([]*models.Phrase, error) {
+	phrases := []*models.Phrase{}
+	for _, sheet := range xlFile.Sheets {
+		if sheet == nil || !strings.EqualFold(sheet.Name, models.SentencesSheet) {
+			continue
+		}
+
+		for _, row := range sheet.Rows {
+			if len(row.Cells) == 0 {
+				continue
+			}
+
+			num, err := strconv.Atoi(strings.TrimSpace(row.Cells[0].String()))
+			if err != nil {
+				continue
+			}
+
+			phrase := &models.Phrase{
+				ID:      num,
+				English: strings.TrimSpace(cellValue(row, 1)),
+				Russian: strings.TrimSpace(cellValue(row, 2)),
+			}
+
+			if phrase.English == "" {
+				return nil, apperrors.ImportPhrasesErr.AppendMessage("empty sentence in row with id", num)
+			}
+
+			for _, field := range strings.Split(cellValue(row, 3), ",") {
+				field = strings.TrimSpace(field)
+				if field == "" {
+					continue
+				}
+
+				wordID, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, apperrors.ImportPhrasesErr.AppendMessage("wrong word id", field, "in row with id", num)
+				}
+
+				phrase.Libraries = append(phrase.Libraries, &models.Library{ID: wordID})
+			}
+
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	return phrases, nil
+}
+
+// MapXLStoLemmas reads the lemmas sheet: library word ID, language code and
+// text.
+func MapXLStoLemmas(xlFile *xlsx.File) ([]*models.Lemma, error) {
+	lemmas := []*models.Lemma{}
+	for _, sheet := range xlFile.Sheets {
+		if sheet == nil || !strings.EqualFold(sheet.Name, models.LemmasSheet) {
+			continue
+		}
+
+		for _, row := range sheet.Rows {
+			if len(row.Cells) == 0 {
+				continue
+			}
+
+			wordID, err := strconv.Atoi(strings.TrimSpace(row.Cells[0].String()))
+			if err != nil {
+				continue
+			}
+
+			lemma := &models.Lemma{
+				LibraryID: wordID,
+				Language:  strings.ToLower(strings.TrimSpace(cellValue(row, 1))),
+				Text:      strings.TrimSpace(cellValue(row, 2)),
+			}
+
+			if lemma.Text == "" || !models.IsLanguage(lemma.Language) {
+				return nil, apperrors.LemmaErr.AppendMessage("empty text or unknown language in row with word id", wordID)
+			}
+
+			lemmas = append(lemmas, lemma)
+		}
+	}
+
+	return lemmas, nil
+}
+
+// ValidateLibrary rejects an import where a row has no English or Russian
+// word or where the same ID is used twice.
+func ValidateLibrary(library []*models.Library) error {
+	ids := make(map[int]bool, len(library))
+	for _, word := range library {
+		if strings.TrimSpace(word.English) == "" || strings.TrimSpace(word.Russian) == "" {
+			return apperrors.ValidateLibraryErr.AppendMessage("empty english or russian in row with id", word.ID)
+		}
+
+		if ids[word.ID] {
+			return apperrors.ValidateLibraryErr.AppendMessage("duplicate id", word.ID)
+		}
+
+		if word.Level != "" && !models.IsCEFRLevel(word.Level) {
+			return apperrors.ValidateLibraryErr.AppendMessage("unknown CEFR level", word.Level, "in row with id", word.ID)
+		}
+
+		if word.Frequency < 0 {
+			return apperrors.ValidateLibraryErr.AppendMessage("negative frequency rank in row with id", word.ID)
+		}
+
+		if word.Register != "" && !models.IsRegister(word.Register) {
+			return apperrors.ValidateLibraryErr.AppendMessage("unknown register", word.Register, "in row with id", word.ID)
+		}
+
+		ids[word.ID] = true
+	}
+
+	return nil
+}
+
+func cellValue(row *xlsx.Row, i int) string {
+	if i >= len(row.Cells) {
+		return ""
+	}
+
+	return row.Cells[i].String()
+}
+
 func capitalizeFirstRune(line string) string {
 	runes := []rune(line)
 	for i, r := range runes {
@@ -198,3 +454,164 @@ func capitalizeFirstRune(line string) string {
 
 	return string(runes)
 }
+
+// cellInt reads a whole number, treating empty and non-numeric cells as 0.
+func cellInt(row *xlsx.Row, i int) int {
+	num, err := strconv.Atoi(strings.TrimSpace(cellValue(row, i)))
+	if err != nil {
+		return 0
+	}
+
+	return num
+}
+
+func MapTranslationsToTranslationItems(translations []*models.Translation) []*responses.TranslationItem {
+	items := make([]*responses.TranslationItem, 0, len(translations))
+	for _, translation := range translations {
+		item := &responses.TranslationItem{
+			Text:        translation.From.Text,
+			Translation: translation.To.Text,
+		}
+
+		if translation.Library != nil {
+			item.LibraryID = translation.Library.ID
+			item.Transcription = translation.Library.Transcription
+			item.RussianStressed = translation.Library.RussianStressed
+			item.Forms = translation.Library.Forms
+			item.Register = translation.Library.Register
+			item.UsageNote = translation.Library.UsageNote
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func MapGlossTokensToGlossItems(tokens []*models.GlossToken) []*responses.GlossItem {
+	items := make([]*responses.GlossItem, 0, len(tokens))
+	for _, token := range tokens {
+		items = append(items, &responses.GlossItem{
+			Text:        token.Text,
+			Lemma:       token.Lemma,
+			Translation: token.Translation,
+			LibraryID:   token.LibraryID,
+		})
+	}
+
+	return items
+}
+
+func MapLibraryToLibraryWordResponses(library []*models.Library) []*responses.LibraryWord {
+	words := make([]*responses.LibraryWord, 0, len(library))
+	for _, word := range library {
+		words = append(words, &responses.LibraryWord{
+			ID:               word.ID,
+			English:          word.English,
+			Russian:          word.Russian,
+			Preposition:      word.Preposition,
+			Theme:            word.Theme,
+			PartsOfSpeech:    word.PartsOfSpeech,
+			Level:            word.Level,
+			Frequency:        word.Frequency,
+			Transcription:    word.Transcription,
+			RussianStressed:  word.RussianStressed,
+			Forms:            word.Forms,
+			Register:         word.Register,
+			UsageNote:        word.UsageNote,
+			TranslationGroup: word.TranslationGroupName,
+			Image:            word.Image,
+		})
+	}
+
+	return words
+}
+
+func MapTopicsToTopicResponses(topics []*models.Topic) []*responses.Topic {
+	resp := make([]*responses.Topic, 0, len(topics))
+	for _, topic := range topics {
+		resp = append(resp, &responses.Topic{
+			ID:       topic.ID,
+			Slug:     topic.Slug,
+			TitleEn:  topic.TitleEn,
+			TitleRu:  topic.TitleRu,
+			Level:    topic.Level,
+			Children: MapTopicsToTopicResponses(topic.Children),
+		})
+	}
+
+	return resp
+}
+
+func MapUserToUserResponse(user *models.User) *responses.UserResponse {
+	resp := &responses.UserResponse{
+		Email:          user.Email,
+		Name:           user.Name,
+		LastName:       user.LastName,
+		Role:           user.Role,
+		Level:          user.Level,
+		SourceLanguage: user.SourceLanguage,
+		TargetLanguage: user.TargetLanguage,
+		KeepHistory:    user.KeepHistory,
+	}
+
+	if user.ID != nil {
+		resp.ID = user.ID.String()
+	}
+
+	return resp
+}
+
+// MapWordsToTestWords leaves the answers out, so they aren't given away
+// before the words are answered.
+func MapWordsToTestWords(words []*models.Word) []*responses.TestWord {
+	testWords := make([]*responses.TestWord, 0, len(words))
+	for i, word := range words {
+		testWords = append(testWords, &responses.TestWord{
+			Index:         i,
+			Prompt:        word.Russian,
+			PartsOfSpeech: word.PartsOfSpeech,
+			Theme:         word.Theme,
+		})
+	}
+
+	return testWords
+}
+
+func MapTestPageDataToTestSession(pageData *models.TestPageData) *responses.TestSession {
+	session := &responses.TestSession{
+		Source: pageData.Pair.Source,
+		Target: pageData.Pair.Target,
+		Words:  MapWordsToTestWords(pageData.Words),
+	}
+
+	if pageData.Deck != nil {
+		session.DeckID = pageData.Deck.ID
+	}
+
+	for _, word := range pageData.Untranslated {
+		session.Untranslated = append(session.Untranslated, word.English)
+	}
+
+	return session
+}
+
+func MapTestPageDataToTestResult(pageData *models.TestPageData) *responses.TestResult {
+	result := &responses.TestResult{Answers: make([]*responses.TestAnswer, 0, len(pageData.Words))}
+	if pageData.Result != nil {
+		result.Right = pageData.Result.Right
+		result.Wrong = pageData.Result.Wrong
+	}
+
+	for i, word := range pageData.Words {
+		result.Answers = append(result.Answers, &responses.TestAnswer{
+			Index:    i,
+			Prompt:   word.Russian,
+			Answer:   word.Answer,
+			Expected: word.English,
+			Right:    word.Right,
+		})
+	}
+
+	return result
+}
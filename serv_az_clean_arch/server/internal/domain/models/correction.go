@@ -0,0 +1,61 @@
+package models
+
+import "gorm.io/gorm"
+
+const (
+	CorrectionPending  = "pending"
+	CorrectionApproved = "approved"
+	CorrectionRejected = "rejected"
+)
+
+// Correction is a user's suggestion to change one field of a library word.
+// OldValue is the field as the user saw it, so a suggestion made before
+// somebody else changed the word isn't applied over that change.
+type Correction struct {
+	gorm.Model
+	ID         int    `json:"id" gorm:"primaryKey"`
+	UserID     string `json:"user_id" gorm:"size:36;index"`
+	WordID     int    `json:"word_id" gorm:"index"`
+	Word       string `json:"word"`
+	Field      string `json:"field" gorm:"size:32"`
+	OldValue   string `json:"old_value"`
+	NewValue   string `json:"new_value"`
+	Comment    string `json:"comment"`
+	Status     string `json:"status" gorm:"size:16;index"`
+	Note       string `json:"note"`
+	ReviewerID string `json:"reviewer_id" gorm:"size:36"`
+}
+
+type CorrectionField struct {
+	Key  string
+	Name string
+}
+
+// CorrectionFields are the library fields users can suggest corrections to,
+// keyed as in the library forms.
+var CorrectionFields = []*CorrectionField{
+	{Key: "english", Name: "English"},
+	{Key: "russian", Name: "Русский"},
+	{Key: "preposition", Name: "Предлог"},
+	{Key: "part_of_speech", Name: "Часть речи"},
+	{Key: "transcription", Name: "Транскрипция"},
+	{Key: "russian_stressed", Name: "Ударение"},
+	{Key: "forms", Name: "Формы"},
+	{Key: "register", Name: "Стиль"},
+	{Key: "usage_note", Name: "Пояснение"},
+}
+
+// CorrectionFieldName returns the name of the field shown to users.
+func CorrectionFieldName(key string) string {
+	for _, field := range CorrectionFields {
+		if field.Key == key {
+			return field.Name
+		}
+	}
+
+	return key
+}
+
+func (c *Correction) FieldName() string {
+	return CorrectionFieldName(c.Field)
+}
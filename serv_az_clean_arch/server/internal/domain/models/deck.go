@@ -0,0 +1,59 @@
+package models
+
+import "gorm.io/gorm"
+
+const (
+	DeckSourceText      = "text"
+	DeckSourceSubtitles = "subtitles"
+	DeckSourceBook      = "book"
+	DeckSourceCustom    = "custom"
+)
+
+// Deck is a private set of words: library words, such as the unknown words of
+// an analyzed text, a movie or a book, and the user's own words.
+type Deck struct {
+	gorm.Model
+	ID          int           `json:"id" gorm:"primaryKey"`
+	UserID      string        `json:"user_id" gorm:"size:36;index"`
+	Name        string        `json:"name" gorm:"size:255"`
+	Source      string        `json:"source" gorm:"size:16"`
+	Words       []*Word       `json:"words" gorm:"many2many:deck_words;"`
+	CustomWords []*CustomWord `json:"custom_words" gorm:"foreignKey:DeckID"`
+}
+
+// CustomWord is a word and translation a user added to a private deck. It is
+// never put into the library or the users' word queues, the progress is kept
+// here. Text is in Language, the one being learned, and LibraryID links the
+// word to a library entry, 0 when it isn't linked.
+type CustomWord struct {
+	gorm.Model
+	ID                  int    `json:"id" gorm:"primaryKey"`
+	DeckID              int    `json:"deck_id" gorm:"index"`
+	UserID              string `json:"user_id" gorm:"size:36;index"`
+	Text                string `json:"text" gorm:"size:255"`
+	Translation         string `json:"translation" gorm:"size:255"`
+	Language            string `json:"language" gorm:"size:8"`
+	TranslationLanguage string `json:"translation_language" gorm:"size:8"`
+	LibraryID           int    `json:"library_id"`
+	Image               string `json:"image" gorm:"->;-:migration"`
+	Learn               bool   `json:"learn"`
+	Learned             bool   `json:"learned"`
+}
+
+// AnalyzedWord is a word of an analyzed text with the number of times it
+// occurs, inflected forms counted together. LibraryID is 0 for words missing
+// from the library.
+type AnalyzedWord struct {
+	Text        string
+	Lemma       string
+	Translation string
+	LibraryID   int
+	Count       int
+}
+
+type TextAnalysis struct {
+	Words   []*AnalyzedWord
+	Tokens  int
+	Learned int
+	Pair    LanguagePair
+}
@@ -0,0 +1,22 @@
+package models
+
+import "gorm.io/gorm"
+
+const (
+	DisputePending  = "pending"
+	DisputeAccepted = "accepted"
+	DisputeRejected = "rejected"
+)
+
+type Dispute struct {
+	gorm.Model
+	ID       int    `json:"id" gorm:"primaryKey"`
+	UserID   string `json:"user_id" gorm:"size:36;index"`
+	WordID   int    `json:"word_id"`
+	Prompt   string `json:"prompt"`
+	Expected string `json:"expected"`
+	Given    string `json:"given"`
+	Language string `json:"language" gorm:"size:8"`
+	Status   string `json:"status" gorm:"size:16;index"`
+	Note     string `json:"note"`
+}
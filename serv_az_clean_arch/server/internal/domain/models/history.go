@@ -0,0 +1,17 @@
+package models
+
+import "gorm.io/gorm"
+
+// LookupHistory is a word a user looked up on the translate page. It is kept
+// only for users who turned the history on. LibraryID is 0 when the word
+// wasn't found.
+type LookupHistory struct {
+	gorm.Model
+	ID        int    `json:"id" gorm:"primaryKey"`
+	UserID    string `json:"user_id" gorm:"size:36;index"`
+	Text      string `json:"text" gorm:"size:255;index"`
+	Source    string `json:"source" gorm:"size:8"`
+	Target    string `json:"target" gorm:"size:8"`
+	LibraryID int    `json:"library_id"`
+	Found     bool   `json:"found"`
+}
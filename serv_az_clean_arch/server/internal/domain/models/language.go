@@ -0,0 +1,107 @@
+package models
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LangEnglish = "en"
+	LangRussian = "ru"
+)
+
+// LemmasSheet is the spreadsheet sheet holding lemmas of the languages that
+// aren't library columns: library word ID, language code and text.
+const LemmasSheet = "lemmas"
+
+type Language struct {
+	Code     string
+	Name     string
+	Alphabet string
+}
+
+// Languages are the languages words can be learned in. English and Russian
+// lemmas mirror the library columns, the others are added by admins.
+var Languages = []*Language{
+	{Code: LangEnglish, Name: "English", Alphabet: "abcdefghijklmnopqrstuvwxyz"},
+	{Code: LangRussian, Name: "Русский", Alphabet: "абвгдеёжзийклмнопрстуфхцчшщъыьэюя"},
+	{Code: "uk", Name: "Українська", Alphabet: "абвгґдеєжзиіїйклмнопрстуфхцчшщьюя"},
+	{Code: "de", Name: "Deutsch", Alphabet: "abcdefghijklmnopqrstuvwxyzäöüß"},
+}
+
+func IsLanguage(code string) bool {
+	return GetLanguage(code) != nil
+}
+
+func GetLanguage(code string) *Language {
+	for _, language := range Languages {
+		if code == language.Code {
+			return language
+		}
+	}
+
+	return nil
+}
+
+// IsExtraSheet tells the spreadsheet sheets that don't hold library words.
+func IsExtraSheet(name string) bool {
+	return strings.EqualFold(name, SentencesSheet) || strings.EqualFold(name, LemmasSheet)
+}
+
+// Lemma is the dictionary form of a word in one language. LibraryID links it
+// to the library word it translates, which carries theme, level and the rest.
+type Lemma struct {
+	gorm.Model
+	ID        int    `json:"id" gorm:"primaryKey"`
+	Language  string `json:"language" gorm:"size:8;index"`
+	Text      string `json:"text" gorm:"size:255;index"`
+	LibraryID int    `json:"library_id" gorm:"index"`
+}
+
+// TranslationLink says two lemmas of different languages translate each
+// other. Links are symmetric.
+type TranslationLink struct {
+	gorm.Model
+	ID          int `json:"id" gorm:"primaryKey"`
+	FromLemmaID int `json:"from_lemma_id" gorm:"index"`
+	ToLemmaID   int `json:"to_lemma_id" gorm:"index"`
+}
+
+// LanguagePair is what a user translates from (Source, the prompt) and into
+// (Target, the answer).
+type LanguagePair struct {
+	Source string
+	Target string
+}
+
+// DefaultLanguagePair is the original course: Russian prompts, English answers.
+var DefaultLanguagePair = LanguagePair{Source: LangRussian, Target: LangEnglish}
+
+func (p LanguagePair) IsDefault() bool {
+	return p == DefaultLanguagePair
+}
+
+// Translation is a lemma with its translation into the other language of the
+// pair, plus the library word when there is one.
+type Translation struct {
+	From    *Lemma
+	To      *Lemma
+	Library *Library
+}
+
+// TranslationLookup holds the translations found for a text. Lemma is the
+// form the text was reduced to when only that form is in the library.
+type TranslationLookup struct {
+	Translations []*Translation
+	Lemma        string
+}
+
+// GlossToken is a word or a multi-word entry of a glossed sentence. Lemma is
+// empty for tokens not found in the library.
+type GlossToken struct {
+	Text        string
+	Lemma       string
+	Translation string
+	LibraryID   int
+}
@@ -0,0 +1,14 @@
+package models
+
+// CEFRLevels are the course levels from the easiest to the hardest.
+var CEFRLevels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+func IsCEFRLevel(level string) bool {
+	for _, known := range CEFRLevels {
+		if level == known {
+			return true
+		}
+	}
+
+	return false
+}
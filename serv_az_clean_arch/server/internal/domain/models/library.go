@@ -6,23 +6,86 @@ import (
 
 type Library struct {
 	gorm.Model
-	ID            int    `json:"ID" gorm:"primaryKey"`
-	English       string `json:"english"`
-	Russian       string `json:"russian"`
-	Preposition   string `json:"preposition"`
-	Theme         string `json:"theme"`
-	PartsOfSpeech string `json:"part_of_speech"`
-	CreatedAt     string `json:"created_at"`
-	Root          string `json:"root"`
+	ID                   int       `json:"ID" gorm:"primaryKey"`
+	English              string    `json:"english"`
+	Russian              string    `json:"russian"`
+	Preposition          string    `json:"preposition"`
+	Theme                string    `json:"theme"`
+	TopicID              int       `json:"topic_id" gorm:"index"`
+	Level                string    `json:"level" gorm:"size:2;index"`
+	Frequency            int       `json:"frequency"`
+	Transcription        string    `json:"transcription"`
+	RussianStressed      string    `json:"russian_stressed"`
+	Forms                string    `json:"forms"`
+	Register             string    `json:"register" gorm:"size:16"`
+	UsageNote            string    `json:"usage_note"`
+	PartsOfSpeech        string    `json:"part_of_speech"`
+	CreatedAt            string    `json:"created_at"`
+	Root                 string    `json:"root"`
+	TranslationGroupID   int       `json:"translation_group_id" gorm:"index"`
+	TranslationGroupName string    `json:"translation_group" gorm:"-"`
+	Image                string    `json:"image" gorm:"size:80"`
+	Phrases              []*Phrase `gorm:"many2many:library_phrases;" json:"library_phrases"`
+	Version              int       `json:"version"`
+	EditedBy             string    `json:"edited_by" gorm:"size:36"`
 	//RightAnswer   int    `json:"rightAnswer" db:"right_answer"`
-	//Phrases       []*Phrase `gorm:"many2many:library_phrases;" json:"library_phrases"`
 	//Exceptions    string    `json:"exceptions"`
 }
 
-// type Phrase struct {
-// 	gorm.Model
-// 	ID        int       `json:"id" gorm:"primaryKey"`
-// 	English   string    `json:"english"`
-// 	Russian   string    `json:"russian"`
-// 	Libraries []Library `gorm:"many2many:library_phrases;" json:"libraries"`
-// }
+// LibraryVersion keeps the values a library word had before an edit. Version
+// is the one the edit replaced and AuthorID the user the edit is credited to,
+// empty for library files.
+type LibraryVersion struct {
+	gorm.Model
+	ID        int    `json:"id" gorm:"primaryKey"`
+	LibraryID int    `json:"library_id" gorm:"index"`
+	Version   int    `json:"version"`
+	AuthorID  string `json:"author_id" gorm:"size:36"`
+	Values    string `json:"values"`
+}
+
+// LibrarySeed records a finished seed of the library from the file at Path.
+// The seed writes word by word, one that stopped halfway has no record and
+// runs again on the next start.
+type LibrarySeed struct {
+	gorm.Model
+	ID    int    `json:"id" gorm:"primaryKey"`
+	Path  string `json:"path"`
+	Words int    `json:"words"`
+}
+
+// TranslationGroup links words that are accepted as answers for each other,
+// plus extra variants that aren't library words themselves.
+type TranslationGroup struct {
+	gorm.Model
+	ID       int                   `json:"id" gorm:"primaryKey"`
+	Name     string                `json:"name"`
+	Words    []*Library            `json:"words" gorm:"-"`
+	Variants []*TranslationVariant `json:"variants" gorm:"foreignKey:TranslationGroupID"`
+}
+
+type TranslationVariant struct {
+	gorm.Model
+	ID                 int    `json:"id" gorm:"primaryKey"`
+	TranslationGroupID int    `json:"translation_group_id" gorm:"index"`
+	English            string `json:"english"`
+}
+
+type DuplicateGroup struct {
+	Kind  string
+	Words []*Library
+}
+
+// SentencesSheet is the spreadsheet sheet holding phrases: ID, English,
+// Russian and the comma separated IDs of the words the phrase is for.
+const SentencesSheet = "sentences"
+
+// Phrase is an example sentence for the words it is linked to. It drives the
+// cloze test.
+type Phrase struct {
+	gorm.Model
+	ID        int        `json:"id" gorm:"primaryKey"`
+	English   string     `json:"english"`
+	Russian   string     `json:"russian"`
+	Libraries []*Library `gorm:"many2many:library_phrases;" json:"libraries"`
+}
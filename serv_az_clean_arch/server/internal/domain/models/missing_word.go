@@ -0,0 +1,14 @@
+package models
+
+import "gorm.io/gorm"
+
+// MissingWord is a text looked up on the translate page without result,
+// normalized and counted once per language. Rows are deleted for good once
+// the word is published or dismissed.
+type MissingWord struct {
+	gorm.Model
+	ID       int    `json:"id" gorm:"primaryKey"`
+	Text     string `json:"text" gorm:"size:255;uniqueIndex:idx_missing_word"`
+	Language string `json:"language" gorm:"size:8;uniqueIndex:idx_missing_word"`
+	Lookups  int    `json:"lookups"`
+}
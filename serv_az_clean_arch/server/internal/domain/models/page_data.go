@@ -6,9 +6,40 @@ type TestResult struct {
 }
 
 type TestPageData struct {
-	Topic       string
-	Words       []*Word
-	Result      *TestResult
-	TestPassed  bool
-	LearnPassed bool
+	Topic      string
+	TopicTitle string
+	Pair       LanguagePair
+	Deck       *Deck
+	Words      []*Word
+	// Untranslated are the words left out for having no translation in the
+	// pair.
+	Untranslated []*Word
+	Result       *TestResult
+	TestPassed   bool
+	LearnPassed  bool
+}
+
+type LibraryPageData struct {
+	Words         []*Library
+	Themes        []string
+	PartsOfSpeech []string
+	Theme         string
+	PartOfSpeech  string
+	Levels        []string
+	Registers     []string
+	Level         string
+	Text          string
+	FilterQuery   string
+	Page          int
+	Pages         int
+	PrevPage      int
+	NextPage      int
+	Total         int64
+}
+
+type LemmasPageData struct {
+	WordID    string
+	Lemmas    []*Lemma
+	Counts    map[string]int64
+	Languages []*Language
 }
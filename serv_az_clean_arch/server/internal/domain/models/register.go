@@ -0,0 +1,15 @@
+package models
+
+// Registers are the usage labels a word can be marked with. An empty register
+// means the word is neutral.
+var Registers = []string{"formal", "informal", "colloquial", "slang", "archaic"}
+
+func IsRegister(register string) bool {
+	for _, known := range Registers {
+		if register == known {
+			return true
+		}
+	}
+
+	return false
+}
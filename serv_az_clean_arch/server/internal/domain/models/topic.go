@@ -0,0 +1,36 @@
+package models
+
+import "gorm.io/gorm"
+
+// Topic is a theme of the library. Library rows point to it by TopicID, and
+// Theme keeps the English title so spreadsheets stay readable.
+type Topic struct {
+	gorm.Model
+	ID            int      `json:"id" gorm:"primaryKey"`
+	Slug          string   `json:"slug" gorm:"size:128;uniqueIndex"`
+	TitleEn       string   `json:"title_en"`
+	TitleRu       string   `json:"title_ru"`
+	DescriptionEn string   `json:"description_en"`
+	DescriptionRu string   `json:"description_ru"`
+	ParentID      int      `json:"parent_id" gorm:"index"`
+	SortOrder     int      `json:"sort_order"`
+	Level         string   `json:"level" gorm:"size:2"`
+	Depth         int      `json:"depth" gorm:"-"`
+	Children      []*Topic `json:"children" gorm:"-"`
+}
+
+func (t *Topic) Title() string {
+	if t.TitleRu != "" {
+		return t.TitleRu
+	}
+
+	return t.TitleEn
+}
+
+func (t *Topic) Description() string {
+	if t.DescriptionRu != "" {
+		return t.DescriptionRu
+	}
+
+	return t.DescriptionEn
+}
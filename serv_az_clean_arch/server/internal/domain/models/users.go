@@ -7,24 +7,50 @@ import (
 
 type User struct {
 	gorm.Model
-	ID       *uuid.UUID `json:"id" gorm:"primaryKey"`
-	Email    string     `json:"user_email" gorm:"unique"`
-	Name     string     `json:"first_name"`
-	LastName string     `json:"last_name"`
-	Password string     `json:"password"`
-	Role     string     `json:"role"`
-	Words    []*Word    `gorm:"many2many:user_words;" json:"user_words"`
-	Learn    []*Word    `gorm:"many2many:user_learn;" json:"user_learn"`
-	Learned  []*Word    `gorm:"many2many:user_learned;" json:"user_learned"`
+	ID             *uuid.UUID `json:"id" gorm:"primaryKey"`
+	Email          string     `json:"user_email" gorm:"unique"`
+	Name           string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Password       string     `json:"password"`
+	Role           string     `json:"role"`
+	Level          string     `json:"level" gorm:"size:2"`
+	SourceLanguage string     `json:"source_language" gorm:"size:8"`
+	TargetLanguage string     `json:"target_language" gorm:"size:8"`
+	KeepHistory    bool       `json:"keep_history"`
+	Words          []*Word    `gorm:"many2many:user_words;" json:"user_words"`
+	Learn          []*Word    `gorm:"many2many:user_learn;" json:"user_learn"`
+	Learned        []*Word    `gorm:"many2many:user_learned;" json:"user_learned"`
+}
+
+// LanguagePair falls back to the default pair for users who haven't chosen one.
+func (u *User) LanguagePair() LanguagePair {
+	if u == nil || u.SourceLanguage == "" || u.TargetLanguage == "" {
+		return DefaultLanguagePair
+	}
+
+	return LanguagePair{Source: u.SourceLanguage, Target: u.TargetLanguage}
 }
 
 type Word struct {
 	gorm.Model
-	ID            int    `json:"id" gorm:"primaryKey"`
-	English       string `json:"english"`
-	Russian       string `json:"russian"`
-	Preposition   string `json:"preposition"`
-	Theme         string `json:"theme"`
-	PartsOfSpeech string `json:"part_of_speech"`
-	Right         bool
+	ID               int    `json:"id" gorm:"primaryKey"`
+	English          string `json:"english"`
+	Russian          string `json:"russian"`
+	Preposition      string `json:"preposition"`
+	Theme            string `json:"theme"`
+	PartsOfSpeech    string `json:"part_of_speech"`
+	Level            string `json:"level" gorm:"size:2;index"`
+	Frequency        int    `json:"frequency"`
+	Transcription    string `json:"transcription"`
+	RussianStressed  string `json:"russian_stressed"`
+	Forms            string `json:"forms"`
+	Register         string `json:"register" gorm:"size:16"`
+	UsageNote        string `json:"usage_note"`
+	Image            string `json:"image" gorm:"->;-:migration"`
+	CustomID         int    `json:"custom_id" gorm:"-"`
+	Right            bool
+	Answer           string `gorm:"-"`
+	Cloze            string `gorm:"-"`
+	ClozeAnswer      string `gorm:"-"`
+	ClozeTranslation string `gorm:"-"`
 }
@@ -1,12 +1,12 @@
 package requests
 
 type CreateUserRequest struct {
-	Id       string
-	Email    string `json:"email"`
-	Name     string `json:"name"`
-	LastName string `json:"last_name"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Id       string `form:"-"`
+	Email    string `json:"email" form:"email"`
+	Name     string `json:"name" form:"name"`
+	LastName string `json:"last_name" form:"last-name"`
+	Password string `json:"password" form:"password"`
+	Role     string `json:"role" form:"role"`
 }
 
 type GetWordsByUsIdAndLimitRequest struct {
@@ -24,11 +24,219 @@ type AddWordToLearnedRequest struct {
 	WordID string `json:"word_id"`
 }
 
+// TranslationRequest is a word or a sentence to translate in a language pair,
+// the default pair when either language is empty. Studied is set on the page
+// the study button returns to.
 type TranslationRequest struct {
-	Word string `json:"word"`
+	Word    string `json:"word" form:"word"`
+	Source  string `json:"source" form:"source"`
+	Target  string `json:"target" form:"target"`
+	Studied bool   `json:"-" form:"studied"`
+}
+
+// SuggestRequest asks for autocomplete suggestions of Key. Limit can only
+// lower the configured limit.
+type SuggestRequest struct {
+	Key    string `json:"key" form:"key"`
+	Limit  int    `json:"limit" form:"limit"`
+	Source string `json:"source" form:"source"`
+	Target string `json:"target" form:"target"`
+}
+
+// StudyRequest puts a translated word into the learn queue and returns to
+// its translation.
+type StudyRequest struct {
+	WordID string `json:"word_id" form:"word_id"`
+	Word   string `json:"word" form:"word"`
+	Source string `json:"source" form:"source"`
+	Target string `json:"target" form:"target"`
+}
+
+type LanguagePairRequest struct {
+	Source string `json:"source" form:"source"`
+	Target string `json:"target" form:"target"`
+}
+
+type RestorePasswordRequest struct {
+	Email string `json:"email" form:"email"`
+}
+
+type UpdatePasswordRequest struct {
+	OldPassword       string `json:"old_password" form:"old_password"`
+	NewPassword       string `json:"new_password" form:"new_password"`
+	NewPasswordSecond string `json:"new_password_second" form:"new_password_second"`
+}
+
+type UserLevelRequest struct {
+	Level string `json:"level" form:"level"`
+}
+
+type HistorySettingsRequest struct {
+	Keep bool `json:"keep" form:"keep"`
+}
+
+// IDRequest names the record a form acts on.
+type IDRequest struct {
+	ID string `json:"id" form:"id"`
+}
+
+// DeckQueryRequest picks the deck a test is made of, the user's words when
+// Deck is empty.
+type DeckQueryRequest struct {
+	Deck string `json:"deck" form:"deck"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" form:"email"`
+	Password string `json:"password" form:"password"`
+}
+
+// TranslationGroupRequest edits a translation group. Action "remove" takes
+// the word or the variant out of the group instead of adding it.
+type TranslationGroupRequest struct {
+	GroupID   string `json:"group_id" form:"group_id"`
+	WordID    string `json:"word_id" form:"word_id"`
+	Name      string `json:"name" form:"name"`
+	English   string `json:"english" form:"english"`
+	Action    string `json:"action" form:"action"`
+	VariantID string `json:"variant_id" form:"variant_id"`
+}
+
+type LibraryFilterRequest struct {
+	Theme         string `json:"theme" form:"theme"`
+	PartsOfSpeech string `json:"part_of_speech" form:"part_of_speech"`
+	Level         string `json:"level" form:"level"`
+	Text          string `json:"text" form:"text"`
+	Page          int    `json:"page" form:"page"`
+	PageSize      int    `json:"page_size" form:"page_size"`
+}
+
+type LibraryWordRequest struct {
+	ID               string `json:"id" form:"id"`
+	English          string `json:"english" form:"english"`
+	Russian          string `json:"russian" form:"russian"`
+	Preposition      string `json:"preposition" form:"preposition"`
+	Theme            string `json:"theme" form:"theme"`
+	PartsOfSpeech    string `json:"part_of_speech" form:"part_of_speech"`
+	Root             string `json:"root" form:"root"`
+	TranslationGroup string `json:"translation_group" form:"translation_group"`
+	Level            string `json:"level" form:"level"`
+	Frequency        string `json:"frequency" form:"frequency"`
+	Transcription    string `json:"transcription" form:"transcription"`
+	RussianStressed  string `json:"russian_stressed" form:"russian_stressed"`
+	Forms            string `json:"forms" form:"forms"`
+	Register         string `json:"register" form:"register"`
+	UsageNote        string `json:"usage_note" form:"usage_note"`
+	Back             string `json:"-" form:"back"`
+}
+
+// AdminWordRequest names a library word of the admin library page. Back is
+// the query of the page to return to.
+type AdminWordRequest struct {
+	ID   string `json:"id" form:"id"`
+	Back string `json:"-" form:"back"`
+}
+
+// MergeWordsRequest merges the duplicates into the canonical word.
+type MergeWordsRequest struct {
+	Canonical  string   `json:"canonical" form:"canonical"`
+	Duplicates []string `json:"duplicates" form:"duplicate"`
+}
+
+type DisputeRequest struct {
+	UserID string `json:"user_id" form:"-"`
+	WordID string `json:"word_id" form:"word_id"`
+}
+
+type ResolveDisputeRequest struct {
+	DisputeID string `json:"dispute_id" form:"id"`
+	Note      string `json:"note" form:"note"`
+}
+
+type TopicRequest struct {
+	ID            string `json:"id" form:"id"`
+	Slug          string `json:"slug" form:"slug"`
+	TitleEn       string `json:"title_en" form:"title_en"`
+	TitleRu       string `json:"title_ru" form:"title_ru"`
+	DescriptionEn string `json:"description_en" form:"description_en"`
+	DescriptionRu string `json:"description_ru" form:"description_ru"`
+	ParentID      string `json:"parent_id" form:"parent_id"`
+	SortOrder     string `json:"sort_order" form:"sort_order"`
+	Level         string `json:"level" form:"level"`
+}
+
+// LemmasRequest opens the lemmas of a library word.
+type LemmasRequest struct {
+	WordID string `json:"word" form:"word"`
+}
+
+type LemmaRequest struct {
+	ID       string `json:"id" form:"id"`
+	WordID   string `json:"word_id" form:"word_id"`
+	Language string `json:"language" form:"language"`
+	Text     string `json:"text" form:"text"`
+}
+
+type DeckRequest struct {
+	Name string `json:"name" form:"name"`
+}
+
+// DeckImportRequest names the deck made of an uploaded file. Learn can only
+// lower the configured number of words put into the learn queue.
+type DeckImportRequest struct {
+	Name  string `json:"name" form:"name"`
+	Learn string `json:"learn" form:"learn"`
+}
+
+// AnalyzeRequest is the text to analyze when no file is uploaded.
+type AnalyzeRequest struct {
+	Text string `json:"text" form:"text"`
+}
+
+// AnalyzedDeckRequest makes a deck of the picked words of an analyzed text.
+type AnalyzedDeckRequest struct {
+	Name  string   `json:"name" form:"name"`
+	Words []string `json:"words" form:"word"`
+}
+
+type CustomWordRequest struct {
+	DeckID      string `json:"deck_id" form:"-"`
+	Text        string `json:"text" form:"text"`
+	Translation string `json:"translation" form:"translation"`
+	Link        bool   `json:"link" form:"link"`
+}
+
+type MissingWordRequest struct {
+	ID      string `json:"id" form:"id"`
+	English string `json:"english" form:"english"`
+	Russian string `json:"russian" form:"russian"`
+	Theme   string `json:"theme" form:"theme"`
+}
+
+// NewCorrectionRequest opens the correction form of a word, with Field
+// picked.
+type NewCorrectionRequest struct {
+	WordID string `json:"word" form:"word"`
+	Field  string `json:"field" form:"field"`
+}
+
+type CorrectionRequest struct {
+	WordID  string `json:"word_id" form:"word_id"`
+	Field   string `json:"field" form:"field"`
+	Value   string `json:"value" form:"value"`
+	Comment string `json:"comment" form:"comment"`
+}
+
+// ResolveCorrectionRequest approves or rejects a correction. A non-empty
+// Value replaces the suggested one on approval.
+type ResolveCorrectionRequest struct {
+	CorrectionID string `json:"correction_id" form:"id"`
+	Value        string `json:"value" form:"value"`
+	Note         string `json:"note" form:"note"`
+}
+
+// AnswersRequest holds the answers to a test or a learn round in the order
+// of its words.
+type AnswersRequest struct {
+	Answers []string `json:"answers" form:"answers"`
 }
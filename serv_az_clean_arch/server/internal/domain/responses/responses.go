@@ -9,8 +9,25 @@ type Result struct {
 }
 
 type GetTranslResponse struct {
-	English string `json:"english"`
-	Russian string `json:"russian"`
+	English         string `json:"english"`
+	Russian         string `json:"russian"`
+	Transcription   string `json:"transcription,omitempty"`
+	RussianStressed string `json:"russian_stressed,omitempty"`
+	Forms           string `json:"forms,omitempty"`
+	Register        string `json:"register,omitempty"`
+	UsageNote       string `json:"usage_note,omitempty"`
+}
+
+type Suggestion struct {
+	Word        string  `json:"word"`
+	Translation string  `json:"translation"`
+	Language    string  `json:"language"`
+	Theme       string  `json:"theme"`
+	Score       float64 `json:"score"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
 }
 
 type LoginResponse struct {
@@ -26,3 +43,132 @@ type WordResp struct {
 	Russian       string `json:"russian"`
 	PartsOfSpeech string `json:"part_of_speech"`
 }
+
+// APIErrorResponse is the error body of the JSON API, built from the code
+// and the message of an AppError.
+type APIErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type TranslationResponse struct {
+	Word         string             `json:"word"`
+	Source       string             `json:"source"`
+	Target       string             `json:"target"`
+	Lemma        string             `json:"lemma,omitempty"`
+	Found        bool               `json:"found"`
+	Translations []*TranslationItem `json:"translations"`
+	DidYouMean   []string           `json:"did_you_mean,omitempty"`
+	Gloss        []*GlossItem       `json:"gloss,omitempty"`
+}
+
+type TranslationItem struct {
+	Text            string `json:"text"`
+	Translation     string `json:"translation"`
+	LibraryID       int    `json:"library_id,omitempty"`
+	Transcription   string `json:"transcription,omitempty"`
+	RussianStressed string `json:"russian_stressed,omitempty"`
+	Forms           string `json:"forms,omitempty"`
+	Register        string `json:"register,omitempty"`
+	UsageNote       string `json:"usage_note,omitempty"`
+}
+
+// GlossItem is a word of a glossed sentence, without Lemma when the library
+// doesn't have it.
+type GlossItem struct {
+	Text        string `json:"text"`
+	Lemma       string `json:"lemma,omitempty"`
+	Translation string `json:"translation,omitempty"`
+	LibraryID   int    `json:"library_id,omitempty"`
+}
+
+type LibraryWord struct {
+	ID               int    `json:"id"`
+	English          string `json:"english"`
+	Russian          string `json:"russian"`
+	Preposition      string `json:"preposition,omitempty"`
+	Theme            string `json:"theme,omitempty"`
+	PartsOfSpeech    string `json:"part_of_speech,omitempty"`
+	Level            string `json:"level,omitempty"`
+	Frequency        int    `json:"frequency,omitempty"`
+	Transcription    string `json:"transcription,omitempty"`
+	RussianStressed  string `json:"russian_stressed,omitempty"`
+	Forms            string `json:"forms,omitempty"`
+	Register         string `json:"register,omitempty"`
+	UsageNote        string `json:"usage_note,omitempty"`
+	TranslationGroup string `json:"translation_group,omitempty"`
+	Image            string `json:"image,omitempty"`
+}
+
+type LibraryPage struct {
+	Words    []*LibraryWord `json:"words"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+	Total    int64          `json:"total"`
+	Pages    int            `json:"pages"`
+}
+
+type Topic struct {
+	ID       int      `json:"id"`
+	Slug     string   `json:"slug"`
+	TitleEn  string   `json:"title_en"`
+	TitleRu  string   `json:"title_ru"`
+	Level    string   `json:"level,omitempty"`
+	Children []*Topic `json:"children,omitempty"`
+}
+
+type UserResponse struct {
+	ID             string `json:"id"`
+	Email          string `json:"email"`
+	Name           string `json:"first_name"`
+	LastName       string `json:"last_name"`
+	Role           string `json:"role"`
+	Level          string `json:"level"`
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	KeepHistory    bool   `json:"keep_history"`
+}
+
+// TestSession is a test or a learn round. Answers are sent in the order of
+// Words.
+type TestSession struct {
+	Source string      `json:"source"`
+	Target string      `json:"target"`
+	DeckID int         `json:"deck_id,omitempty"`
+	Words  []*TestWord `json:"words"`
+	// Untranslated are the English words left out for having no
+	// translation in the pair.
+	Untranslated []string `json:"untranslated,omitempty"`
+}
+
+type TestWord struct {
+	Index         int    `json:"index"`
+	Prompt        string `json:"prompt"`
+	PartsOfSpeech string `json:"part_of_speech,omitempty"`
+	Theme         string `json:"theme,omitempty"`
+}
+
+type TestResult struct {
+	Right   int           `json:"right"`
+	Wrong   int           `json:"wrong"`
+	Answers []*TestAnswer `json:"answers"`
+}
+
+type TestAnswer struct {
+	Index    int    `json:"index"`
+	Prompt   string `json:"prompt"`
+	Answer   string `json:"answer"`
+	Expected string `json:"expected"`
+	Right    bool   `json:"right"`
+}
+
+// LearnResult holds the words to answer again, none once the round is
+// learned.
+type LearnResult struct {
+	Passed bool        `json:"passed"`
+	Words  []*TestWord `json:"words"`
+}
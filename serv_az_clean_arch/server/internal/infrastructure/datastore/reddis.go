@@ -1,13 +1,41 @@
 package datastore
 
-import "server/internal/domain/models"
+import (
+	"server/internal/domain/models"
+	"sync"
+)
 
+// HashDB caches the users by ID for the handlers, which run concurrently.
 type HashDB struct {
-	DB map[string]*models.User
+	mu sync.RWMutex
+	db map[string]*models.User
 }
 
 func InitHashDB() *HashDB {
 
 	var hashTableUsers = make(map[string]*models.User)
-	return &HashDB{DB: hashTableUsers}
+	return &HashDB{db: hashTableUsers}
+}
+
+func (h *HashDB) Get(userID string) (*models.User, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	user, ok := h.db[userID]
+	return user, ok
+}
+
+func (h *HashDB) Set(userID string, user *models.User) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.db[userID] = user
+}
+
+// Delete drops the cached user, so the next request loads the changes.
+func (h *HashDB) Delete(userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.db, userID)
 }
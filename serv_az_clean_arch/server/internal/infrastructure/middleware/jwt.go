@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"server/internal/apperrors"
+	"server/internal/domain/responses"
 	"server/internal/infrastructure/webtemplate.go"
+	"strings"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo"
@@ -18,14 +20,7 @@ const (
 func JWTAuthentication(jc *JWTMiddlewareConfig, blacklist *Blacklist, tmpls *webtemplate.WebTemplates) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			cookies := c.Request().Cookies()
-			var tokenGet string
-			for _, cookie := range cookies {
-				if cookie.Name == "user_token_translator" {
-					tokenGet = cookie.Value
-				}
-			}
-
+			tokenGet := cookieToken(c)
 			if tokenGet == "" {
 				appErr := apperrors.JWTMiddleware.AppendMessage("Vars Authorization")
 				log.Error(appErr)
@@ -83,3 +78,113 @@ func JWTAuthentication(jc *JWTMiddlewareConfig, blacklist *Blacklist, tmpls *web
 		}
 	}
 }
+
+// OptionalJWTAuthentication sets the user of a valid token the way
+// JWTAuthentication does and lets guests through, for pages open to both.
+func OptionalJWTAuthentication(jc *JWTMiddlewareConfig, blacklist *Blacklist) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id, role, appErr := parseUserToken(cookieToken(c), jc, blacklist); appErr == nil {
+				c.Set("role", role)
+				c.Set("id", id)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// APIJWTAuthentication is JWTAuthentication for the JSON API: it reads the
+// token from the Authorization header instead of the cookie and answers with
+// a JSON error instead of the login page.
+func APIJWTAuthentication(jc *JWTMiddlewareConfig, blacklist *Blacklist) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id, role, appErr := parseUserToken(bearerToken(c), jc, blacklist)
+			if appErr != nil {
+				log.Error(appErr)
+				return c.JSON(appErr.HTTPCode, responses.APIErrorResponse{
+					Error: responses.APIError{Code: appErr.Code, Message: appErr.Message},
+				})
+			}
+
+			c.Set("role", role)
+			c.Set("id", id)
+			return next(c)
+		}
+	}
+}
+
+// APIOptionalJWTAuthentication is OptionalJWTAuthentication for the JSON
+// API: it reads the token from the Authorization header.
+func APIOptionalJWTAuthentication(jc *JWTMiddlewareConfig, blacklist *Blacklist) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id, role, appErr := parseUserToken(bearerToken(c), jc, blacklist); appErr == nil {
+				c.Set("role", role)
+				c.Set("id", id)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// parseUserToken returns the user of the token when the token is valid and
+// hasn't been logged out.
+func parseUserToken(tokenGet string, jc *JWTMiddlewareConfig, blacklist *Blacklist) (string, string, *apperrors.AppError) {
+	if tokenGet == "" {
+		return "", "", apperrors.JWTMiddleware.AppendMessage("Vars Authorization")
+	}
+
+	if blacklist.IsTokenBlacklisted(tokenGet) {
+		return "", "", apperrors.JWTMiddleware.AppendMessage("Token is blacklisted")
+	}
+
+	token, err := jwt.Parse(tokenGet, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, apperrors.JWTMiddleware.AppendMessage("invalid signature method")
+		}
+
+		return []byte(jc.SecretKey), nil
+	})
+	if err != nil {
+		return "", "", apperrors.JWTMiddleware.AppendMessage(err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", "", apperrors.JWTMiddleware.AppendMessage("The token has expired or is invalid")
+	}
+
+	role, _ := claims["role"].(string)
+	id, _ := claims["id"].(string)
+	if role == "" || id == "" {
+		return "", "", apperrors.JWTMiddleware.AppendMessage("Role or id not found in token")
+	}
+
+	return id, role, nil
+}
+
+// cookieToken reads the token from the cookie set at login.
+func cookieToken(c echo.Context) string {
+	var tokenGet string
+	for _, cookie := range c.Request().Cookies() {
+		if cookie.Name == "user_token_translator" {
+			tokenGet = cookie.Value
+		}
+	}
+
+	return tokenGet
+}
+
+// bearerToken reads the token from the Authorization header the API clients
+// send.
+func bearerToken(c echo.Context) string {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(header, "Bearer ") {
+		return ""
+	}
+
+	return strings.TrimPrefix(header, "Bearer ")
+}
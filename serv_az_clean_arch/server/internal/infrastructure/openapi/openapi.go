@@ -0,0 +1,303 @@
+package openapi
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/responses"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+const (
+	cookieAuth = "cookieAuth"
+	bearerAuth = "bearerAuth"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations of a path by the lower-cased method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	AdminOnly   bool                  `json:"x-admin-only,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// NewDocument describes the routes of the echo route table with the route
+// descriptions of this package. Routes without a description are in the
+// document too, the error names them and the descriptions of routes which
+// aren't registered.
+func NewDocument(routes []*echo.Route) (*Document, error) {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Переводчик",
+			Description: "Pages of the translator, its forms and the JSON API v1.",
+			Version:     "1.0.0",
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				cookieAuth: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        "user_token_translator",
+					Description: "JWT set by the sign in page. Pages answer with the sign in page without it.",
+				},
+				bearerAuth: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "JWT returned by POST /api/v1/login.",
+				},
+			},
+		},
+	}
+
+	s := schemas{}
+	registered := map[string]bool{}
+	undescribed := []string{}
+	for _, rt := range routes {
+		key := rt.Method + " " + rt.Path
+		registered[key] = true
+
+		desc, ok := routeTable[key]
+		if !ok {
+			undescribed = append(undescribed, key)
+			desc = route{summary: "Not described"}
+		}
+
+		path, params := pathParams(rt.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+
+		doc.Paths[path][strings.ToLower(rt.Method)] = desc.operation(rt.Method, params, s)
+	}
+
+	unregistered := []string{}
+	for key := range routeTable {
+		if !registered[key] {
+			unregistered = append(unregistered, key)
+		}
+	}
+
+	doc.Components.Schemas = s
+	if len(undescribed) > 0 || len(unregistered) > 0 {
+		sort.Strings(undescribed)
+		sort.Strings(unregistered)
+		return doc, apperrors.OpenAPIRoutesErr.AppendMessage("routes without a description", undescribed, "descriptions of unregistered routes", unregistered)
+	}
+
+	return doc, nil
+}
+
+// pathParams turns an echo path into an OpenAPI one and returns the names of
+// its parameters. The wildcard of static files is named file.
+func pathParams(path string) (string, []string) {
+	params := []string{}
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, ":"):
+			params = append(params, part[1:])
+			parts[i] = "{" + part[1:] + "}"
+		case part == "*":
+			params = append(params, "file")
+			parts[i] = "{file}"
+		}
+	}
+
+	return strings.Join(parts, "/"), params
+}
+
+func (r route) operation(method string, pathParams []string, s schemas) *Operation {
+	op := &Operation{
+		Summary:   r.summary,
+		Responses: map[string]*Response{},
+		AdminOnly: r.admin,
+	}
+
+	if r.tag != "" {
+		op.Tags = []string{r.tag}
+	}
+
+	if r.admin {
+		op.Description = "Admin only, other users get the error page."
+	}
+
+	for _, name := range pathParams {
+		op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	form := r.formSchema()
+	query := formSchema(r.query)
+	if method == http.MethodGet {
+		query = form
+	}
+
+	for _, name := range sortedKeys(query.Properties) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "query", Schema: query.Properties[name]})
+	}
+
+	switch {
+	case r.body != nil:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{
+			echo.MIMEApplicationJSON: {Schema: s.ref(r.body)},
+			echo.MIMEApplicationForm: {Schema: form},
+		}}
+	case method != http.MethodGet && len(r.files) > 0:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{echo.MIMEMultipartForm: {Schema: form}}}
+	case method != http.MethodGet && (len(form.Properties) > 0 || form.AdditionalProperties != nil):
+		op.RequestBody = &RequestBody{Content: map[string]*MediaType{echo.MIMEApplicationForm: {Schema: form}}}
+	}
+
+	switch r.access {
+	case guest:
+		op.Security = []map[string][]string{{}, {cookieAuth: {}}}
+	case member:
+		op.Security = []map[string][]string{{cookieAuth: {}}}
+	case apiGuest:
+		op.Security = []map[string][]string{{}, {bearerAuth: {}}}
+	case apiMember:
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+		op.Responses["401"] = &Response{
+			Description: "There is no valid token",
+			Content:     map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: s.ref(responses.APIErrorResponse{})}},
+		}
+	}
+
+	switch r.reply {
+	case page:
+		op.Responses["200"] = &Response{Description: "HTML page", Content: map[string]*MediaType{"text/html": {}}}
+	case redirect:
+		op.Responses["303"] = &Response{
+			Description: "Redirects to the page showing the result",
+			Headers:     map[string]*Header{echo.HeaderLocation: {Schema: &Schema{Type: "string"}}},
+		}
+	case static:
+		op.Responses["200"] = &Response{
+			Description: "Static file",
+			Content:     map[string]*MediaType{"image/*": {Schema: &Schema{Type: "string", Format: "binary"}}},
+		}
+	case asset:
+		op.Responses["200"] = &Response{
+			Description: "Script or stylesheet",
+			Content: map[string]*MediaType{
+				"text/javascript": {Schema: &Schema{Type: "string"}},
+				"text/css":        {Schema: &Schema{Type: "string"}},
+			},
+		}
+	case download:
+		op.Responses["200"] = &Response{
+			Description: "File attachment",
+			Content:     map[string]*MediaType{echo.MIMEOctetStream: {Schema: &Schema{Type: "string", Format: "binary"}}},
+		}
+	case jsonReply:
+		content := &MediaType{}
+		if r.result != nil {
+			content.Schema = s.ref(r.result)
+		}
+
+		op.Responses["200"] = &Response{Description: "OK", Content: map[string]*MediaType{echo.MIMEApplicationJSON: content}}
+	}
+
+	if r.failure != nil {
+		op.Responses["default"] = &Response{
+			Description: "Error with the status of its AppError",
+			Content:     map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: s.ref(r.failure)}},
+		}
+	}
+
+	return op
+}
+
+// formSchema describes the fields the handler reads from the query or the
+// form: the form tags of the request type followed by the uploaded files.
+func (r route) formSchema() *Schema {
+	form := r.form
+	if form == nil {
+		form = r.body
+	}
+
+	schema := formSchema(form)
+	for _, name := range r.files {
+		schema.Properties[name] = &Schema{Type: "string", Format: "binary"}
+	}
+
+	if r.answers {
+		schema.Description = "answer0, answer1... hold the answers in the order of the words on the page"
+		schema.AdditionalProperties = &Schema{Type: "string"}
+	}
+
+	return schema
+}
+
+func sortedKeys(properties map[string]*Schema) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
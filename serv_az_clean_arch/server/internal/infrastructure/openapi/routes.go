@@ -0,0 +1,175 @@
+package openapi
+
+import (
+	"server/internal/domain/requests"
+	"server/internal/domain/responses"
+)
+
+// access is the JWT middleware of a route.
+type access int
+
+const (
+	public    access = iota
+	guest            // OptionalJWTAuthentication, the user is known when signed in
+	member           // JWTAuthentication
+	apiMember        // APIJWTAuthentication
+	apiGuest         // APIOptionalJWTAuthentication
+)
+
+// reply is what a route answers with.
+type reply int
+
+const (
+	page reply = iota
+	redirect
+	jsonReply
+	download
+	static
+	asset
+)
+
+// route describes a route of router.NewRouter. Form is the request type
+// whose form tags name the fields the handler reads, files are the uploads
+// next to them. A GET reads them from the query, any other method from the
+// form. Query is the request type a POST reads from its query. Body is the
+// request type bound from JSON or a form.
+type route struct {
+	summary string
+	tag     string
+	access  access
+	admin   bool
+	form    interface{}
+	files   []string
+	query   interface{}
+	answers bool
+	body    interface{}
+	reply   reply
+	result  interface{}
+	failure interface{}
+}
+
+// routeTable holds the description of every route by its method and echo
+// path. NewDocument reports the routes missing here.
+var routeTable = map[string]route{
+	//-------static files----
+	"GET /images":        {summary: "Images of the pages", tag: "static", reply: static},
+	"GET /images/*":      {summary: "Images of the pages", tag: "static", reply: static},
+	"GET /word-images":   {summary: "Pictures of library words", tag: "static", reply: static},
+	"GET /word-images/*": {summary: "Pictures of library words", tag: "static", reply: static},
+
+	//------------HOME----translate
+	"GET /":             {summary: "Home page", tag: "translate"},
+	"GET /quick-answer": {summary: "Autocomplete suggestions", tag: "translate", form: requests.SuggestRequest{}, reply: jsonReply, result: []*responses.Suggestion{}, failure: responses.ErrorResponse{}},
+	"GET /translate":    {summary: "Translate a word or gloss a sentence", tag: "translate", access: guest, form: requests.TranslationRequest{}},
+	"POST /translate":   {summary: "Translate a word or gloss a sentence", tag: "translate", access: guest, form: requests.TranslationRequest{}},
+
+	//---------------user-CRUD----------------
+	"GET /registration":           {summary: "Sign up page", tag: "users"},
+	"POST /registration":          {summary: "Sign up", tag: "users", form: requests.CreateUserRequest{}},
+	"GET /login":                  {summary: "Sign in page", tag: "users"},
+	"POST /login":                 {summary: "Sign in, the page sets the token cookie", tag: "users", form: requests.LoginRequest{}},
+	"GET /user-restore-password":  {summary: "Restore password page", tag: "users"},
+	"POST /user-restore-password": {summary: "Email a new password", tag: "users", form: requests.RestorePasswordRequest{}, reply: redirect},
+	"GET /logout":                 {summary: "Sign out page", tag: "users"},
+	"POST /logout":                {summary: "Sign out, the token is blacklisted", tag: "users", reply: redirect},
+	"GET /user-info":              {summary: "Profile of the user", tag: "users", access: member},
+	"GET /user-update":            {summary: "Profile form", tag: "users", access: member},
+	"POST /user-update":           {summary: "Update the profile", tag: "users", access: member, form: requests.CreateUserRequest{}},
+	"POST /user-level":            {summary: "Set the level of the user", tag: "users", access: member, form: requests.UserLevelRequest{}, reply: redirect},
+	"POST /user-languages":        {summary: "Set the language pair the user studies", tag: "users", access: member, form: requests.LanguagePairRequest{}, reply: redirect},
+	"GET /user-update-password":   {summary: "Password form", tag: "users", access: member},
+	"POST /user-update-password":  {summary: "Change the password", tag: "users", access: member, form: requests.UpdatePasswordRequest{}},
+
+	//-------Update LIBRARY-------------------
+	"GET /info-users":                        {summary: "All users", tag: "admin", access: member, admin: true},
+	"GET /library-update":                    {summary: "Library upload page", tag: "admin", access: member, admin: true},
+	"POST /library-update":                   {summary: "Replace the library with an xlsx file", tag: "admin", access: member, admin: true, files: []string{"fileToUpload"}, reply: redirect},
+	"GET /library-download":                  {summary: "Library as an xlsx file", tag: "admin", access: member, admin: true, reply: download},
+	"GET /admin/library":                     {summary: "Library words", tag: "admin", access: member, admin: true, form: requests.LibraryFilterRequest{}},
+	"POST /admin/library/create":             {summary: "Add a library word", tag: "admin", access: member, admin: true, form: requests.LibraryWordRequest{}, reply: redirect},
+	"POST /admin/library/update":             {summary: "Edit a library word", tag: "admin", access: member, admin: true, form: requests.LibraryWordRequest{}, reply: redirect},
+	"POST /admin/library/delete":             {summary: "Delete a library word", tag: "admin", access: member, admin: true, form: requests.AdminWordRequest{}, reply: redirect},
+	"GET /admin/library/duplicates":          {summary: "Duplicate library words", tag: "admin", access: member, admin: true},
+	"POST /admin/library/merge":              {summary: "Merge duplicates into a word", tag: "admin", access: member, admin: true, form: requests.MergeWordsRequest{}, reply: redirect},
+	"POST /admin/library/image":              {summary: "Upload the picture of a word", tag: "admin", access: member, admin: true, form: requests.AdminWordRequest{}, files: []string{"image"}, reply: redirect},
+	"POST /admin/library/image/delete":       {summary: "Delete the picture of a word", tag: "admin", access: member, admin: true, form: requests.AdminWordRequest{}, reply: redirect},
+	"POST /admin/library/images":             {summary: "Upload a zip of word pictures", tag: "admin", access: member, admin: true, files: []string{"archive"}, reply: redirect},
+	"GET /admin/translation-groups":          {summary: "Translation groups", tag: "admin", access: member, admin: true},
+	"POST /admin/translation-groups/create":  {summary: "Add a translation group", tag: "admin", access: member, admin: true, form: requests.TranslationGroupRequest{}, reply: redirect},
+	"POST /admin/translation-groups/delete":  {summary: "Delete a translation group", tag: "admin", access: member, admin: true, form: requests.TranslationGroupRequest{}, reply: redirect},
+	"POST /admin/translation-groups/word":    {summary: "Add a word to a group or remove it", tag: "admin", access: member, admin: true, form: requests.TranslationGroupRequest{}, reply: redirect},
+	"POST /admin/translation-groups/variant": {summary: "Add a variant to a group or remove it", tag: "admin", access: member, admin: true, form: requests.TranslationGroupRequest{}, reply: redirect},
+	"GET /admin/lemmas":                      {summary: "Lemmas of a library word", tag: "admin", access: member, admin: true, form: requests.LemmasRequest{}},
+	"POST /admin/lemmas/create":              {summary: "Add a lemma", tag: "admin", access: member, admin: true, form: requests.LemmaRequest{}, reply: redirect},
+	"POST /admin/lemmas/delete":              {summary: "Delete a lemma", tag: "admin", access: member, admin: true, form: requests.LemmaRequest{}, reply: redirect},
+	"GET /admin/topics":                      {summary: "Topics", tag: "admin", access: member, admin: true},
+	"POST /admin/topics/create":              {summary: "Add a topic", tag: "admin", access: member, admin: true, form: requests.TopicRequest{}, reply: redirect},
+	"POST /admin/topics/update":              {summary: "Edit a topic", tag: "admin", access: member, admin: true, form: requests.TopicRequest{}, reply: redirect},
+	"POST /admin/topics/delete":              {summary: "Delete a topic", tag: "admin", access: member, admin: true, form: requests.IDRequest{}, reply: redirect},
+	"GET /admin/missing-words":               {summary: "Words the translator didn't find", tag: "admin", access: member, admin: true},
+	"POST /admin/missing-words/publish":      {summary: "Add a missing word to the library", tag: "admin", access: member, admin: true, form: requests.MissingWordRequest{}, reply: redirect},
+	"POST /admin/missing-words/dismiss":      {summary: "Drop a missing word", tag: "admin", access: member, admin: true, form: requests.IDRequest{}, reply: redirect},
+	"GET /admin/corrections":                 {summary: "Corrections to review", tag: "admin", access: member, admin: true},
+	"POST /admin/corrections/approve":        {summary: "Approve a correction", tag: "admin", access: member, admin: true, form: requests.ResolveCorrectionRequest{}, reply: redirect},
+	"POST /admin/corrections/reject":         {summary: "Reject a correction", tag: "admin", access: member, admin: true, form: requests.ResolveCorrectionRequest{}, reply: redirect},
+	"GET /admin/disputes":                    {summary: "Disputes to review", tag: "admin", access: member, admin: true},
+	"POST /admin/disputes/accept":            {summary: "Accept a dispute", tag: "admin", access: member, admin: true, form: requests.ResolveDisputeRequest{}, reply: redirect},
+	"POST /admin/disputes/reject":            {summary: "Reject a dispute", tag: "admin", access: member, admin: true, form: requests.ResolveDisputeRequest{}, reply: redirect},
+
+	//-------TESTS---LEARN--------------------
+	"GET /test":            {summary: "Test of the user's words or of a deck", tag: "tests", access: member, form: requests.DeckQueryRequest{}},
+	"POST /test":           {summary: "Check the test", tag: "tests", access: member, answers: true},
+	"GET /learn":           {summary: "Learn the words answered wrong", tag: "tests", access: member, form: requests.DeckQueryRequest{}},
+	"POST /learn":          {summary: "Check the learn round", tag: "tests", access: member, answers: true},
+	"GET /cloze":           {summary: "Cloze test", tag: "tests", access: member, form: requests.DeckQueryRequest{}},
+	"POST /cloze":          {summary: "Check the cloze test", tag: "tests", access: member, answers: true},
+	"GET /test-pictures":   {summary: "Picture test", tag: "tests", access: member, form: requests.DeckQueryRequest{}},
+	"POST /test-pictures":  {summary: "Check the picture test", tag: "tests", access: member, answers: true},
+	"GET /thematic/:slug":  {summary: "Test of a topic", tag: "tests", access: member},
+	"POST /thematic/:slug": {summary: "Check the test of a topic", tag: "tests", access: member, answers: true},
+	"GET /test-thematic":   {summary: "Topics to test", tag: "tests", access: member},
+	"POST /dispute":        {summary: "Dispute an answer of the last test", tag: "tests", access: member, form: requests.DisputeRequest{}, reply: redirect},
+	"GET /disputes":        {summary: "Disputes of the user", tag: "tests", access: member},
+
+	//-------TEXT ANALYZER--------------------
+	"GET /analyze":       {summary: "Text analyzer", tag: "decks", access: member},
+	"POST /analyze":      {summary: "Analyze a text or a subtitle or EPUB file", tag: "decks", access: member, form: requests.AnalyzeRequest{}, files: []string{"file"}},
+	"POST /analyze/deck": {summary: "Make a deck of the analyzed words", tag: "decks", access: member, form: requests.AnalyzedDeckRequest{}, reply: redirect},
+
+	//-------DECKS--------------------
+	"GET /decks":                   {summary: "Decks of the user", tag: "decks", access: member},
+	"POST /decks":                  {summary: "Add a deck", tag: "decks", access: member, form: requests.DeckRequest{}, reply: redirect},
+	"POST /decks/import":           {summary: "Import a subtitle or EPUB file as a deck", tag: "decks", access: member, form: requests.DeckImportRequest{}, files: []string{"file"}, reply: redirect},
+	"GET /decks/:id":               {summary: "Deck", tag: "decks", access: member},
+	"POST /decks/:id/delete":       {summary: "Delete a deck", tag: "decks", access: member, reply: redirect},
+	"POST /decks/:id/words":        {summary: "Add a word to a deck", tag: "decks", access: member, form: requests.CustomWordRequest{}, reply: redirect},
+	"POST /decks/:id/words/delete": {summary: "Delete a word of a deck", tag: "decks", access: member, form: requests.IDRequest{}, reply: redirect},
+
+	//-------TRANSLATION HISTORY--------------------
+	"POST /translate/study":  {summary: "Learn a translated word", tag: "history", access: member, form: requests.StudyRequest{}, reply: redirect},
+	"GET /history":           {summary: "Lookup history", tag: "history", access: member},
+	"POST /history/settings": {summary: "Turn the lookup history on or off", tag: "history", access: member, form: requests.HistorySettingsRequest{}, reply: redirect},
+	"POST /history/clear":    {summary: "Clear the lookup history", tag: "history", access: member, reply: redirect},
+
+	//-------LIBRARY CORRECTIONS--------------------
+	"GET /corrections/new": {summary: "Correction form", tag: "corrections", access: member, form: requests.NewCorrectionRequest{}},
+	"POST /corrections":    {summary: "Suggest a correction", tag: "corrections", access: member, form: requests.CorrectionRequest{}, reply: redirect},
+	"GET /corrections":     {summary: "Corrections of the user", tag: "corrections", access: member},
+
+	//-------API v1--------------------
+	"POST /api/v1/login":         {summary: "Token for the API", tag: "api", body: requests.LoginRequest{}, reply: jsonReply, result: responses.LoginResponse{}, failure: responses.APIErrorResponse{}},
+	"GET /api/v1/translate":      {summary: "Translate a word or gloss a sentence", tag: "api", access: apiGuest, form: requests.TranslationRequest{}, reply: jsonReply, result: responses.TranslationResponse{}, failure: responses.APIErrorResponse{}},
+	"GET /api/v1/autocomplete":   {summary: "Autocomplete suggestions", tag: "api", form: requests.SuggestRequest{}, reply: jsonReply, result: []*responses.Suggestion{}, failure: responses.APIErrorResponse{}},
+	"GET /api/v1/library":        {summary: "Page of the library", tag: "api", access: apiMember, form: requests.LibraryFilterRequest{}, reply: jsonReply, result: responses.LibraryPage{}, failure: responses.APIErrorResponse{}},
+	"GET /api/v1/topics":         {summary: "Topic tree", tag: "api", access: apiMember, reply: jsonReply, result: []*responses.Topic{}, failure: responses.APIErrorResponse{}},
+	"GET /api/v1/me":             {summary: "Current user", tag: "api", access: apiMember, reply: jsonReply, result: responses.UserResponse{}, failure: responses.APIErrorResponse{}},
+	"POST /api/v1/tests":         {summary: "Start a test", tag: "api", access: apiMember, query: requests.DeckQueryRequest{}, reply: jsonReply, result: responses.TestSession{}, failure: responses.APIErrorResponse{}},
+	"POST /api/v1/tests/answers": {summary: "Check the test", tag: "api", access: apiMember, body: requests.AnswersRequest{}, reply: jsonReply, result: responses.TestResult{}, failure: responses.APIErrorResponse{}},
+	"POST /api/v1/learn":         {summary: "Start a learn round", tag: "api", access: apiMember, query: requests.DeckQueryRequest{}, reply: jsonReply, result: responses.TestSession{}, failure: responses.APIErrorResponse{}},
+	"POST /api/v1/learn/answers": {summary: "Check the learn round", tag: "api", access: apiMember, body: requests.AnswersRequest{}, reply: jsonReply, result: responses.LearnResult{}, failure: responses.APIErrorResponse{}},
+
+	//-------API docs--------------------
+	"GET /api/openapi.json":  {summary: "This document", tag: "docs", reply: jsonReply},
+	"GET /api/docs":          {summary: "Page that renders this document", tag: "docs"},
+	"GET /api/docs/assets/*": {summary: "Swagger UI script and styles of the docs page", tag: "docs", reply: asset},
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Schema is the part of an OpenAPI schema object the documented types need.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// schemas collects the JSON types the operations refer to, so each of them
+// is described once in the components.
+type schemas map[string]*Schema
+
+// ref describes v by its json tags in the components and returns the
+// reference to it. Slices refer to their elements.
+func (s schemas) ref(v interface{}) *Schema {
+	return s.jsonSchema(reflect.TypeOf(v))
+}
+
+func (s schemas) jsonSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.jsonSchema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := s[t.Name()]; !ok {
+			schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+			s[t.Name()] = schema
+			for _, field := range fields(t, "json") {
+				schema.Properties[field.name] = s.jsonSchema(field.typ)
+			}
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+
+	return scalarSchema(t)
+}
+
+// formSchema describes the fields of v named by its form tags, the fields
+// the handlers fill with bindForm. Fields tagged "-" aren't sent by the form.
+func formSchema(v interface{}) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	if v == nil {
+		return schema
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for _, field := range fields(t, "form") {
+		if field.typ.Kind() == reflect.Slice {
+			schema.Properties[field.name] = &Schema{Type: "array", Items: scalarSchema(field.typ.Elem())}
+			continue
+		}
+
+		schema.Properties[field.name] = scalarSchema(field.typ)
+	}
+
+	return schema
+}
+
+type field struct {
+	name string
+	typ  reflect.Type
+}
+
+// fields returns the exported fields of t named by the tag, without the
+// fields the tag leaves out.
+func fields(t reflect.Type, tag string) []field {
+	list := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name := strings.Split(structField.Tag.Get(tag), ",")[0]
+		if !structField.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		list = append(list, field{name: name, typ: structField.Type})
+	}
+
+	return list
+}
+
+func scalarSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+
+	return &Schema{Type: "string"}
+}
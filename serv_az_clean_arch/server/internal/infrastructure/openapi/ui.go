@@ -0,0 +1,18 @@
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// ui holds swagger-ui-dist 5.18.2 (Apache-2.0), see ui/NOTICE.
+//
+//go:embed ui/swagger-ui-bundle.js ui/swagger-ui.css
+var ui embed.FS
+
+// UIAssets returns the Swagger UI script and styles of the docs page, served
+// by the server itself so the page works without a CDN.
+func UIAssets() fs.FS {
+	assets, _ := fs.Sub(ui, "ui")
+	return assets
+}
@@ -1,7 +1,9 @@
 package router
 
 import (
+	"net/http"
 	"server/internal/infrastructure/middleware"
+	"server/internal/infrastructure/openapi"
 	"server/internal/infrastructure/webtemplate.go"
 	"server/internal/interface/controller"
 
@@ -9,15 +11,14 @@ import (
 	echoMiddleware "github.com/labstack/echo/middleware"
 )
 
-func NewRouter(e *echo.Echo, srv controller.AppController, secretKey string, tmpls *webtemplate.WebTemplates) *echo.Echo {
+func NewRouter(e *echo.Echo, srv controller.AppController, secretKey, imagesPath string, tmpls *webtemplate.WebTemplates) (*echo.Echo, error) {
 	//e.Use(echoMiddleware.Logger())
 	e.Use(echoMiddleware.Recover())
 	//-------init images ------------------
 	e.Static("/images", "templates/images")
+	e.Static("/word-images", imagesPath)
 	//------------HOME----translate
 	e.GET("/", func(context echo.Context) error { return srv.HandlerController.HomeHandler(context) })
-	e.GET("/translate", func(context echo.Context) error { return srv.HandlerController.GetTranslationHandler(context) })
-	e.POST("/translate", func(context echo.Context) error { return srv.HandlerController.GetTranslationHandler(context) })
 	e.GET("/quick-answer", func(context echo.Context) error { return srv.HandlerController.QuickAnswerHandler(context) })
 	//---------------user-CRUD----------------
 	e.GET("/registration", func(context echo.Context) error { return srv.HandlerController.CreateUserHandler(context) })
@@ -32,10 +33,14 @@ func NewRouter(e *echo.Echo, srv controller.AppController, secretKey string, tmp
 	e.GET("/logout", srv.HandlerController.LogoutHandler(blackList))
 	//---------------JWT-------------------------
 	jwtConfig := middleware.JWTMiddlewareConfig{SecretKey: secretKey}
+	e.GET("/translate", srv.HandlerController.GetTranslationHandler, middleware.OptionalJWTAuthentication(&jwtConfig, blackList))
+	e.POST("/translate", srv.HandlerController.GetTranslationHandler, middleware.OptionalJWTAuthentication(&jwtConfig, blackList))
 
 	e.GET("/user-info", srv.HandlerController.GetUserByIdHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/user-update", srv.HandlerController.UpdateUserHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.POST("/user-update", srv.HandlerController.UpdateUserHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/user-level", srv.HandlerController.UpdateUserLevelHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/user-languages", srv.HandlerController.UpdateUserLanguagesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/user-update-password", srv.HandlerController.UpdateUserPasswordHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.POST("/user-update-password", srv.HandlerController.UpdateUserPasswordHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 
@@ -44,15 +49,98 @@ func NewRouter(e *echo.Echo, srv controller.AppController, secretKey string, tmp
 	e.POST("/library-update", srv.HandlerController.UpdateLibraryHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/library-update", srv.HandlerController.UpdateLibraryHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/library-download", srv.HandlerController.DownloadHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/library", srv.HandlerController.AdminLibraryHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/create", srv.HandlerController.AdminLibraryCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/update", srv.HandlerController.AdminLibraryUpdateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/delete", srv.HandlerController.AdminLibraryDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/library/duplicates", srv.HandlerController.AdminDuplicatesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/merge", srv.HandlerController.AdminMergeDuplicatesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/image", srv.HandlerController.AdminWordImageHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/image/delete", srv.HandlerController.AdminWordImageDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/library/images", srv.HandlerController.AdminImagesZipHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/translation-groups", srv.HandlerController.AdminTranslationGroupsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/translation-groups/create", srv.HandlerController.AdminTranslationGroupCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/translation-groups/delete", srv.HandlerController.AdminTranslationGroupDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/translation-groups/word", srv.HandlerController.AdminTranslationGroupWordHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/translation-groups/variant", srv.HandlerController.AdminTranslationGroupVariantHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/lemmas", srv.HandlerController.AdminLemmasHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/lemmas/create", srv.HandlerController.AdminLemmaCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/lemmas/delete", srv.HandlerController.AdminLemmaDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/topics", srv.HandlerController.AdminTopicsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/topics/create", srv.HandlerController.AdminTopicCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/topics/update", srv.HandlerController.AdminTopicUpdateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/topics/delete", srv.HandlerController.AdminTopicDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	//-------TESTS---LEARN--------------------
 	e.POST("/test", srv.HandlerController.TestHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/test", srv.HandlerController.TestHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.POST("/learn", srv.HandlerController.LearnHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	e.GET("/learn", srv.HandlerController.LearnHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/cloze", srv.HandlerController.ClozeHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/cloze", srv.HandlerController.ClozeHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/test-pictures", srv.HandlerController.PictureTestHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/test-pictures", srv.HandlerController.PictureTestHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	//-------TESTS--------thematic test----------------------
-	e.POST("/thematic/:theme", srv.HandlerController.TestUniversalHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
-	e.GET("/thematic/:theme", srv.HandlerController.TestUniversalHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/thematic/:slug", srv.HandlerController.TestUniversalHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/thematic/:slug", srv.HandlerController.TestUniversalHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
 	//e.POST("/test-thematic", srv.HandlerController.ThemesHandler, middleware.JWTAuthentication(&jwtConfig, blackList))
 	e.GET("/test-thematic", srv.HandlerController.ThemesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
-	return e
+	//-------TEXT ANALYZER--------------------
+	e.GET("/analyze", srv.HandlerController.TextAnalyzerHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/analyze", srv.HandlerController.TextAnalyzerHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/analyze/deck", srv.HandlerController.TextAnalyzerDeckHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------DECKS--------------------
+	e.GET("/decks", srv.HandlerController.DecksHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/decks", srv.HandlerController.DeckCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/decks/import", srv.HandlerController.DeckImportHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/decks/:id", srv.HandlerController.DeckHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/decks/:id/delete", srv.HandlerController.DeckDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/decks/:id/words", srv.HandlerController.CustomWordCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/decks/:id/words/delete", srv.HandlerController.CustomWordDeleteHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------TRANSLATION HISTORY--------------------
+	e.POST("/translate/study", srv.HandlerController.StudyWordHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/history", srv.HandlerController.HistoryHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/history/settings", srv.HandlerController.HistorySettingsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/history/clear", srv.HandlerController.HistoryClearHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------LIBRARY CORRECTIONS--------------------
+	e.GET("/corrections/new", srv.HandlerController.CorrectionFormHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/corrections", srv.HandlerController.CorrectionCreateHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/corrections", srv.HandlerController.UserCorrectionsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/corrections", srv.HandlerController.AdminCorrectionsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/corrections/approve", srv.HandlerController.AdminApproveCorrectionHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/corrections/reject", srv.HandlerController.AdminRejectCorrectionHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------MISSING WORDS--------------------
+	e.GET("/admin/missing-words", srv.HandlerController.AdminMissingWordsHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/missing-words/publish", srv.HandlerController.AdminMissingWordPublishHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/missing-words/dismiss", srv.HandlerController.AdminMissingWordDismissHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------DISPUTES--------------------
+	e.POST("/dispute", srv.HandlerController.DisputeHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/disputes", srv.HandlerController.UserDisputesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.GET("/admin/disputes", srv.HandlerController.AdminDisputesHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/disputes/accept", srv.HandlerController.AdminAcceptDisputeHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	e.POST("/admin/disputes/reject", srv.HandlerController.AdminRejectDisputeHandler, middleware.JWTAuthentication(&jwtConfig, blackList, tmpls))
+	//-------API v1--------------------
+	e.POST("/api/v1/login", srv.HandlerController.APILoginHandler)
+	e.GET("/api/v1/translate", srv.HandlerController.APITranslateHandler, middleware.APIOptionalJWTAuthentication(&jwtConfig, blackList))
+	e.GET("/api/v1/autocomplete", srv.HandlerController.APIAutocompleteHandler)
+	e.GET("/api/v1/library", srv.HandlerController.APILibraryHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.GET("/api/v1/topics", srv.HandlerController.APITopicsHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.GET("/api/v1/me", srv.HandlerController.APIMeHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.POST("/api/v1/tests", srv.HandlerController.APIStartTestHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.POST("/api/v1/tests/answers", srv.HandlerController.APICheckTestHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.POST("/api/v1/learn", srv.HandlerController.APIStartLearnHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	e.POST("/api/v1/learn/answers", srv.HandlerController.APICheckLearnHandler, middleware.APIJWTAuthentication(&jwtConfig, blackList))
+	//-------API docs--------------------
+	doc := &openapi.Document{}
+	e.GET("/api/openapi.json", srv.HandlerController.OpenAPIHandler(doc))
+	e.GET("/api/docs", srv.HandlerController.APIDocsHandler)
+	e.GET("/api/docs/assets/*", echo.WrapHandler(http.StripPrefix("/api/docs/assets/", http.FileServer(http.FS(openapi.UIAssets())))))
+
+	// The document describes every route, so it is built once they are all registered.
+	built, err := openapi.NewDocument(e.Routes())
+	if err != nil {
+		return nil, err
+	}
+
+	*doc = *built
+	return e, nil
 }
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"reflect"
+	"server/internal/infrastructure/middleware"
+	"server/internal/infrastructure/openapi"
+	"server/internal/infrastructure/webtemplate.go"
+	"server/internal/interface/controller"
+	"testing"
+
+	"github.com/labstack/echo"
+)
+
+// fakeController embeds the interface it stands for, only the handler
+// factories run while the routes are registered.
+type fakeController struct {
+	controller.HandleController
+}
+
+func (f *fakeController) LogoutHandler(blacklist *middleware.Blacklist) echo.HandlerFunc {
+	return func(c echo.Context) error { return nil }
+}
+
+func (f *fakeController) OpenAPIHandler(doc *openapi.Document) echo.HandlerFunc {
+	return func(c echo.Context) error { return nil }
+}
+
+// TestNewRouterDescribesRoutes fails when a route is registered without a
+// description in the openapi route table or a description has no route.
+func TestNewRouterDescribesRoutes(t *testing.T) {
+	app := controller.AppController{HandlerController: &fakeController{}}
+	e, err := NewRouter(echo.New(), app, "secret", t.TempDir(), &webtemplate.WebTemplates{})
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	doc, err := openapi.NewDocument(e.Routes())
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		method   string
+		security []map[string][]string
+		query    []string
+	}{
+		{path: "/translate", method: "get", security: []map[string][]string{{}, {"cookieAuth": {}}}, query: []string{"source", "studied", "target", "word"}},
+		{path: "/user-info", method: "get", security: []map[string][]string{{"cookieAuth": {}}}, query: []string{}},
+		{path: "/test", method: "get", security: []map[string][]string{{"cookieAuth": {}}}, query: []string{"deck"}},
+		{path: "/api/v1/translate", method: "get", security: []map[string][]string{{}, {"bearerAuth": {}}}, query: []string{"source", "studied", "target", "word"}},
+		{path: "/api/v1/tests", method: "post", security: []map[string][]string{{"bearerAuth": {}}}, query: []string{"deck"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			op := doc.Paths[tt.path][tt.method]
+			if op == nil {
+				t.Fatalf("no operation %s %s", tt.method, tt.path)
+			}
+
+			query := []string{}
+			for _, param := range op.Parameters {
+				if param.In == "query" {
+					query = append(query, param.Name)
+				}
+			}
+
+			if !reflect.DeepEqual(op.Security, tt.security) || !reflect.DeepEqual(query, tt.query) {
+				t.Errorf("security = %v, query = %q, want %v, %q", op.Security, query, tt.security, tt.query)
+			}
+		})
+	}
+}
@@ -20,6 +20,24 @@ const (
 	updateUserPassword  = "update_user_password"
 	usersInfo           = "users_info"
 	restoreUserPassword = "restore_user_password"
+	adminLibrary        = "admin_library"
+	adminDuplicates     = "admin_duplicates"
+	adminGroups         = "admin_translation_groups"
+	disputes            = "disputes"
+	adminDisputes       = "admin_disputes"
+	adminTopics         = "admin_topics"
+	cloze               = "cloze"
+	testPictures        = "test_pictures"
+	adminLemmas         = "admin_lemmas"
+	analyzer            = "analyzer"
+	userDecks           = "decks"
+	userDeck            = "deck"
+	history             = "history"
+	adminMissingWords   = "admin_missing_words"
+	correctionForm      = "correction"
+	corrections         = "corrections"
+	adminCorrections    = "admin_corrections"
+	apiDocs             = "api_docs"
 )
 
 //var hashTableUsers = make(map[string]*models.User)
@@ -150,6 +150,150 @@ func InitializeTemplates(logger *logrus.Logger) (*WebTemplates, error) {
 	}
 	tmplsList[restoreUserPassword] = tmpl
 
+	tmpl, err = template.ParseFiles("templates/admin_library.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminLibrary] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_duplicates.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminDuplicates] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_translation_groups.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminGroups] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/analyzer.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[analyzer] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/decks.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[userDecks] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/deck.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[userDeck] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/history.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[history] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_missing_words.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminMissingWords] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/correction.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[correctionForm] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/corrections.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[corrections] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_corrections.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminCorrections] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/api_docs.html")
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[apiDocs] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/disputes.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[disputes] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_disputes.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminDisputes] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_topics.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminTopics] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/cloze.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[cloze] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/test_pictures.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[testPictures] = tmpl
+
+	tmpl, err = template.ParseFiles("templates/admin_lemmas.html", header, footer)
+	if err != nil {
+		appErr := apperrors.InitializeTemplatesErr.AppendMessage(err)
+		logger.Error(appErr)
+		return nil, appErr
+	}
+	tmplsList[adminLemmas] = tmpl
+
 	logger.Info("Templates have been registered")
 	tmpls := &WebTemplates{Templates: tmplsList}
 	return tmpls, nil
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+// maxTextUpload bounds pasted texts and uploaded .txt files.
+const maxTextUpload = 1 << 20
+
+type analyzerPage struct {
+	Analysis *models.TextAnalysis
+	Text     string
+}
+
+//------------Text analyzer----------------------
+
+func (srv *handleController) TextAnalyzerHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.TextAnalyzerHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := analyzerPage{}
+	if c.Request().Method == http.MethodPost {
+		c.Request().Body = http.MaxBytesReader(c.Response().Writer, c.Request().Body, maxTextUpload)
+		text, err := analyzerText(c)
+		if err != nil {
+			appErr := apperrors.AnalyzeTextErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		page.Text = text
+		page.Analysis, err = srv.deckInteractor.AnalyzeText(c.Request().Context(), userID, text, srv.languagePair(c, userID))
+		if err != nil {
+			appErr := err.(*apperrors.AppError)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	if err := srv.tmpls.Templates[analyzer].ExecuteTemplate(c.Response().Writer, analyzer, page); err != nil {
+		appErr := apperrors.TextAnalyzerHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+// analyzerText prefers an uploaded file to the pasted text.
+func analyzerText(c echo.Context) (string, error) {
+	file, _, err := c.Request().FormFile("file")
+	if err == http.ErrMissingFile {
+		req := &requests.AnalyzeRequest{}
+		bindForm(c, req)
+		return req.Text, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (srv *handleController) TextAnalyzerDeckHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.TextAnalyzerHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := c.Request().ParseForm(); err != nil {
+		appErr := apperrors.CreateDeckFromTextErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.AnalyzedDeckRequest{}
+	bindForm(c, req)
+	_, err := srv.deckInteractor.CreateDeckFromText(c.Request().Context(), userID, req.Name, req.Words)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/learn", http.StatusSeeOther)
+	return nil
+}
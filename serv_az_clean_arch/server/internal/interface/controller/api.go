@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/mappers"
+	"server/internal/domain/requests"
+	"server/internal/domain/responses"
+	"server/internal/usercase/comparer"
+	"strings"
+
+	"github.com/labstack/echo"
+)
+
+//------------JSON API v1----------------------
+
+// APILoginHandler returns a token for the Authorization header of the other
+// API requests.
+func (srv *handleController) APILoginHandler(c echo.Context) error {
+	loginRequest := &requests.LoginRequest{}
+	if err := c.Bind(loginRequest); err != nil {
+		return srv.respondAPIErr(c, apperrors.APIRequestErr.AppendMessage(err))
+	}
+
+	loginResp, err := srv.userInteractor.SignInUserWithJWT(c.Request().Context(), loginRequest, srv.config.Server.SecretKey, srv.config.Server.ExpirationJWTInSeconds)
+	if err != nil {
+		srv.log.Error(err)
+		return srv.respondAPIErr(c, apperrors.SignInUserWithJWTErr.AppendMessage("wrong email or password"))
+	}
+
+	return c.JSON(http.StatusOK, loginResp)
+}
+
+// APITranslateHandler is the translate page as JSON. A guest gets the
+// translation too, a signed in user also gets the lookup kept in the history.
+func (srv *handleController) APITranslateHandler(c echo.Context) error {
+	req := &requests.TranslationRequest{}
+	bindForm(c, req)
+	word := strings.TrimSpace(req.Word)
+	if word == "" {
+		return srv.respondAPIErr(c, apperrors.APIRequestErr.AppendMessage("word is required"))
+	}
+
+	responseData := Rsvp{Pair: pairOrDefault(req.Source, req.Target), Word: word, UserID: optionalUserID(c)}
+	if err := srv.translatePage(c, &responseData); err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, &responses.TranslationResponse{
+		Word:         responseData.Word,
+		Source:       responseData.Pair.Source,
+		Target:       responseData.Pair.Target,
+		Lemma:        responseData.Lemma,
+		Found:        len(responseData.Translations) > 0 || len(responseData.Gloss) > 0,
+		Translations: mappers.MapTranslationsToTranslationItems(responseData.Translations),
+		DidYouMean:   responseData.DidYouMean,
+		Gloss:        mappers.MapGlossTokensToGlossItems(responseData.Gloss),
+	})
+}
+
+func (srv *handleController) APIAutocompleteHandler(c echo.Context) error {
+	suggestions, err := srv.suggestions(c)
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
+
+// APILibraryHandler returns a page of the library filtered the way the admin
+// library page is.
+func (srv *handleController) APILibraryHandler(c echo.Context) error {
+	filter := &requests.LibraryFilterRequest{}
+	bindForm(c, filter)
+
+	words, total, err := srv.libraryInteractor.GetLibraryPage(c.Request().Context(), filter)
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, &responses.LibraryPage{
+		Words:    mappers.MapLibraryToLibraryWordResponses(words),
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Total:    total,
+		Pages:    int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+	})
+}
+
+func (srv *handleController) APITopicsHandler(c echo.Context) error {
+	topics, err := srv.libraryInteractor.GetTopicTree(c.Request().Context())
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mappers.MapTopicsToTopicResponses(topics))
+}
+
+func (srv *handleController) APIMeHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		return srv.respondAPIErr(c, apperrors.APIHandlerErr.AppendMessage("there is no user in request"))
+	}
+
+	user, err := srv.userInteractor.GetUserById(c.Request().Context(), userID)
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	srv.hashDB.Set(userID, user)
+	return c.JSON(http.StatusOK, mappers.MapUserToUserResponse(user))
+}
+
+// APIStartTestHandler starts a test of the user's words, or of the deck in
+// the query. It replaces a test started on the test page.
+func (srv *handleController) APIStartTestHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		return srv.respondAPIErr(c, apperrors.APIHandlerErr.AppendMessage("there is no user in request"))
+	}
+
+	pageData, err := srv.startTest(c, userID)
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mappers.MapTestPageDataToTestSession(pageData))
+}
+
+func (srv *handleController) APICheckTestHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		return srv.respondAPIErr(c, apperrors.APIHandlerErr.AppendMessage("there is no user in request"))
+	}
+
+	answers := &requests.AnswersRequest{}
+	if err := c.Bind(answers); err != nil {
+		return srv.respondAPIErr(c, apperrors.APIRequestErr.AppendMessage(err))
+	}
+
+	if pageData, ok := comparer.HashTableWords.Get(userID); ok && pageData.TestPassed {
+		return srv.respondAPIErr(c, apperrors.TestSessionErr.AppendMessage("the test has been checked already"))
+	}
+
+	if err := srv.comparer.CompareTestAnswers(c.Request().Context(), userID, answers.Answers); err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	pageData, _ := comparer.HashTableWords.Get(userID)
+	return c.JSON(http.StatusOK, mappers.MapTestPageDataToTestResult(pageData))
+}
+
+func (srv *handleController) APIStartLearnHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		return srv.respondAPIErr(c, apperrors.APIHandlerErr.AppendMessage("there is no user in request"))
+	}
+
+	pageData, err := srv.startLearn(c, userID)
+	if err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	return c.JSON(http.StatusOK, mappers.MapTestPageDataToTestSession(pageData))
+}
+
+// APICheckLearnHandler checks a learn round and returns the words answered
+// wrong, to be answered again in the order given.
+func (srv *handleController) APICheckLearnHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		return srv.respondAPIErr(c, apperrors.APIHandlerErr.AppendMessage("there is no user in request"))
+	}
+
+	answers := &requests.AnswersRequest{}
+	if err := c.Bind(answers); err != nil {
+		return srv.respondAPIErr(c, apperrors.APIRequestErr.AppendMessage(err))
+	}
+
+	if pageData, ok := comparer.HashTableWordsLearn.Get(userID); ok && pageData.LearnPassed {
+		return srv.respondAPIErr(c, apperrors.TestSessionErr.AppendMessage("the words have been learned already"))
+	}
+
+	if err := srv.comparer.CompareLearnAnswers(c.Request().Context(), userID, answers.Answers); err != nil {
+		return srv.respondAPIErr(c, err)
+	}
+
+	pageData, _ := comparer.HashTableWordsLearn.Get(userID)
+	return c.JSON(http.StatusOK, &responses.LearnResult{
+		Passed: pageData.LearnPassed,
+		Words:  mappers.MapWordsToTestWords(pageData.Words),
+	})
+}
+
+// respondAPIErr answers with the code and the message of the error and its
+// status, 500 for errors without one.
+func (srv *handleController) respondAPIErr(c echo.Context, err error) error {
+	appErr, ok := err.(*apperrors.AppError)
+	if !ok {
+		appErr = apperrors.APIHandlerErr.AppendMessage(err)
+	}
+
+	status := appErr.HTTPCode
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	if status < http.StatusInternalServerError {
+		srv.log.Info(appErr)
+	} else {
+		srv.log.Error(appErr)
+	}
+
+	return c.JSON(status, responses.APIErrorResponse{
+		Error: responses.APIError{Code: appErr.Code, Message: appErr.Message},
+	})
+}
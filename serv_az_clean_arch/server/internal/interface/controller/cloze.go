@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/comparer"
+
+	"github.com/labstack/echo"
+)
+
+// clozeCandidates is how many of the user's words are looked through to find
+// ones that have example sentences.
+const (
+	clozeCandidates     = "50"
+	clozeDeckCandidates = 50
+	clozeWords          = 5
+)
+
+func (srv *handleController) ClozeHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.ClozeHandlerErr.AppendMessage("UserIdErr")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if c.Request().Method == http.MethodGet {
+		var deck *models.Deck
+		var words []*models.Word
+		var err error
+		if deckID := deckQuery(c); deckID != "" {
+			deck, words, err = srv.deckInteractor.GetDeckWords(c.Request().Context(), userID, deckID, models.DefaultLanguagePair, false, clozeDeckCandidates)
+		} else {
+			getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: clozeCandidates}
+			words, err = srv.userInteractor.GetWordsByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+		}
+
+		if err != nil {
+			appErr := err.(*apperrors.AppError)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		clozeWords, err := srv.libraryInteractor.BuildClozeTest(c.Request().Context(), words, clozeWords)
+		if err != nil {
+			appErr := err.(*apperrors.AppError)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		pageData := &models.TestPageData{
+			Pair:       models.DefaultLanguagePair,
+			Deck:       deck,
+			Words:      clozeWords,
+			TestPassed: false,
+		}
+
+		comparer.HashTableCloze.Set(userID, pageData)
+
+		err = srv.tmpls.Templates[cloze].ExecuteTemplate(c.Response().Writer, cloze, pageData)
+		if err != nil {
+			appErr := apperrors.ClozeHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	if c.Request().Method == http.MethodPost {
+		err := c.Request().ParseForm()
+		if err != nil {
+			appErr := apperrors.ClozeHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		err = srv.comparer.CompareClozeWords(c.Request(), userID)
+		if err != nil {
+			appErr := apperrors.ClozeHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		pageData, _ := comparer.HashTableCloze.Get(userID)
+		err = srv.tmpls.Templates[cloze].ExecuteTemplate(c.Response().Writer, cloze, pageData)
+		if err != nil {
+			appErr := apperrors.ClozeHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	return nil
+}
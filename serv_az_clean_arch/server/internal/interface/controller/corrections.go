@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+type correctionPage struct {
+	Word   *models.Library
+	Fields []*models.CorrectionField
+	Field  string
+}
+
+type correctionsPage struct {
+	Corrections []*models.Correction
+	Approved    int
+}
+
+//------------Library corrections suggested by users----------------------
+
+// CorrectionFormHandler shows a library word with the form to suggest a
+// correction to one of its fields.
+func (srv *handleController) CorrectionFormHandler(c echo.Context) error {
+	req := &requests.NewCorrectionRequest{}
+	bindForm(c, req)
+	word, err := srv.correctionInteractor.GetCorrectableWord(c.Request().Context(), req.WordID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := correctionPage{Word: word, Fields: models.CorrectionFields, Field: req.Field}
+	if err := srv.tmpls.Templates[correctionForm].ExecuteTemplate(c.Response().Writer, correctionForm, page); err != nil {
+		appErr := apperrors.CorrectionHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) CorrectionCreateHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.CorrectionHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.CorrectionRequest{}
+	bindForm(c, req)
+
+	if err := srv.correctionInteractor.SuggestCorrection(c.Request().Context(), userID, req); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/corrections", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) UserCorrectionsHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.CorrectionHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	userCorrections, err := srv.correctionInteractor.GetUserCorrections(c.Request().Context(), userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := correctionsPage{Corrections: userCorrections}
+	for _, correction := range userCorrections {
+		if correction.Status == models.CorrectionApproved {
+			page.Approved++
+		}
+	}
+
+	if err := srv.tmpls.Templates[corrections].ExecuteTemplate(c.Response().Writer, corrections, page); err != nil {
+		appErr := apperrors.CorrectionHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminCorrectionsHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	pending, err := srv.correctionInteractor.GetPendingCorrections(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := srv.tmpls.Templates[adminCorrections].ExecuteTemplate(c.Response().Writer, adminCorrections, pending); err != nil {
+		appErr := apperrors.CorrectionHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+// AdminApproveCorrectionHandler applies the correction, with the value the
+// admin edited it to if there is one.
+func (srv *handleController) AdminApproveCorrectionHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	reviewerID, _, _ := srv.getIdANdRoleFromRequest(c)
+	err := srv.correctionInteractor.ApproveCorrection(c.Request().Context(), reviewerID, resolveCorrectionRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/corrections", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) AdminRejectCorrectionHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	reviewerID, _, _ := srv.getIdANdRoleFromRequest(c)
+	err := srv.correctionInteractor.RejectCorrection(c.Request().Context(), reviewerID, resolveCorrectionRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/corrections", http.StatusSeeOther)
+	return nil
+}
+
+func resolveCorrectionRequestFromForm(c echo.Context) *requests.ResolveCorrectionRequest {
+	req := &requests.ResolveCorrectionRequest{}
+	bindForm(c, req)
+	return req
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"strconv"
+
+	"github.com/labstack/echo"
+)
+
+// maxDeckUpload bounds uploaded subtitles and books.
+const maxDeckUpload = 20 << 20
+
+type decksPage struct {
+	Decks []*models.Deck
+}
+
+type deckPage struct {
+	Deck *models.Deck
+	Pair models.LanguagePair
+}
+
+//------------Decks----------------------
+
+func (srv *handleController) DecksHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	decks, err := srv.deckInteractor.GetUserDecks(c.Request().Context(), userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := srv.tmpls.Templates[userDecks].ExecuteTemplate(c.Response().Writer, userDecks, decksPage{Decks: decks}); err != nil {
+		appErr := apperrors.DecksHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+// DeckImportHandler builds a deck from uploaded subtitles (.srt, .vtt) or an
+// EPUB book. Learn may lower the configured number of words put into the
+// learn queue.
+func (srv *handleController) DeckImportHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response().Writer, c.Request().Body, maxDeckUpload)
+	file, header, err := c.Request().FormFile("file")
+	if err != nil {
+		appErr := apperrors.ImportDeckErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		appErr := apperrors.ImportDeckErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.DeckImportRequest{}
+	bindForm(c, req)
+	learnLimit := srv.config.Server.DeckLearnLimit
+	if requested, err := strconv.Atoi(req.Learn); err == nil && requested >= 0 && requested < learnLimit {
+		learnLimit = requested
+	}
+
+	_, err = srv.deckInteractor.ImportDeck(c.Request().Context(), userID, req.Name, header.Filename, data, srv.languagePair(c, userID), learnLimit)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/decks", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) DeckCreateHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.DeckRequest{}
+	bindForm(c, req)
+	deck, err := srv.deckInteractor.CreateDeck(c.Request().Context(), userID, req.Name)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToDeck(c, strconv.Itoa(deck.ID))
+	return nil
+}
+
+func (srv *handleController) DeckHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	deck, err := srv.deckInteractor.GetDeck(c.Request().Context(), userID, c.Param("id"))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := deckPage{Deck: deck, Pair: srv.languagePair(c, userID)}
+	if err := srv.tmpls.Templates[userDeck].ExecuteTemplate(c.Response().Writer, userDeck, page); err != nil {
+		appErr := apperrors.DecksHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) DeckDeleteHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := srv.deckInteractor.DeleteDeck(c.Request().Context(), userID, c.Param("id")); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/decks", http.StatusSeeOther)
+	return nil
+}
+
+// CustomWordCreateHandler adds the user's own word to the deck in the user's
+// language pair.
+func (srv *handleController) CustomWordCreateHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.CustomWordRequest{DeckID: c.Param("id")}
+	bindForm(c, req)
+
+	_, err := srv.deckInteractor.AddCustomWord(c.Request().Context(), userID, req, srv.languagePair(c, userID))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToDeck(c, req.DeckID)
+	return nil
+}
+
+func (srv *handleController) CustomWordDeleteHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DecksHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.IDRequest{}
+	bindForm(c, req)
+	if err := srv.deckInteractor.DeleteCustomWord(c.Request().Context(), userID, req.ID); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToDeck(c, c.Param("id"))
+	return nil
+}
+
+func (srv *handleController) redirectToDeck(c echo.Context, deckID string) {
+	http.Redirect(c.Response().Writer, c.Request(), "/decks/"+url.PathEscape(deckID), http.StatusSeeOther)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/requests"
+	"server/internal/usercase/comparer"
+	"strconv"
+
+	"github.com/labstack/echo"
+)
+
+//------------Disputes of test answers----------------------
+
+func (srv *handleController) DisputeHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.DisputeRequest{UserID: userID}
+	bindForm(c, req)
+	wordID, err := strconv.Atoi(req.WordID)
+	if err != nil {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	word, pair, found := comparer.AnsweredWord(req.UserID, wordID)
+	if !found {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage("there is no answer to dispute")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.disputeInteractor.FileDispute(c.Request().Context(), req.UserID, word, pair.Target)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/disputes", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) UserDisputesHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	userDisputes, err := srv.disputeInteractor.GetUserDisputes(c.Request().Context(), userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.tmpls.Templates[disputes].ExecuteTemplate(c.Response().Writer, disputes, userDisputes)
+	if err != nil {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminDisputesHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	pending, err := srv.disputeInteractor.GetPendingDisputes(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.tmpls.Templates[adminDisputes].ExecuteTemplate(c.Response().Writer, adminDisputes, pending)
+	if err != nil {
+		appErr := apperrors.DisputeHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminAcceptDisputeHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	dispute, err := srv.disputeInteractor.AcceptDispute(c.Request().Context(), resolveDisputeRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	comparer.AcceptAnswer(dispute.UserID, dispute.WordID, dispute.Given)
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/disputes", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) AdminRejectDisputeHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	err := srv.disputeInteractor.RejectDispute(c.Request().Context(), resolveDisputeRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/disputes", http.StatusSeeOther)
+	return nil
+}
+
+func resolveDisputeRequestFromForm(c echo.Context) *requests.ResolveDisputeRequest {
+	req := &requests.ResolveDisputeRequest{}
+	bindForm(c, req)
+	return req
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"reflect"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"strconv"
+
+	"github.com/labstack/echo"
+)
+
+// bindForm fills the request req points to from the form, or the query of a
+// GET, by the form tags of its fields, the names the API docs describe.
+// Fields tagged "-" are left to the handler. A bool is set by any value, as a
+// checked checkbox sends "on", an int that doesn't parse stays 0 and a
+// []string gets every value of its name.
+func bindForm(c echo.Context, req interface{}) {
+	v := reflect.ValueOf(req).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		value := c.FormValue(name)
+		switch field := v.Field(i); field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			field.SetBool(value != "")
+		case reflect.Int:
+			n, _ := strconv.Atoi(value)
+			field.SetInt(int64(n))
+		case reflect.Slice:
+			field.Set(reflect.ValueOf(append([]string{}, c.Request().Form[name]...)))
+		}
+	}
+}
+
+// deckQuery returns the deck a test is made of, empty for the user's words.
+func deckQuery(c echo.Context) string {
+	req := &requests.DeckQueryRequest{}
+	bindForm(c, req)
+	return req.Deck
+}
+
+// pairOrDefault returns the pair of the request, the default one when either
+// language is missing.
+func pairOrDefault(source, target string) models.LanguagePair {
+	if source == "" || target == "" {
+		return models.DefaultLanguagePair
+	}
+
+	return models.LanguagePair{Source: source, Target: target}
+}
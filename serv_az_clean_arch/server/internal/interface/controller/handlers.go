@@ -7,12 +7,16 @@ import (
 	"server/internal/config"
 	"server/internal/domain/models"
 	"server/internal/domain/requests"
+	"server/internal/domain/responses"
 	"server/internal/infrastructure/datastore"
 	"server/internal/infrastructure/middleware"
+	"server/internal/infrastructure/openapi"
 	"server/internal/infrastructure/webtemplate.go"
 	"server/internal/usercase/comparer"
 	"server/internal/usercase/interactor"
+	"server/internal/usercase/morphology"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/labstack/echo"
 	"github.com/sirupsen/logrus"
@@ -24,13 +28,18 @@ const (
 )
 
 type handleController struct {
-	comparer          comparer.Comparer
-	libraryInteractor interactor.LibraryInteractor
-	userInteractor    interactor.UserInteractor
-	hashDB            *datastore.HashDB
-	log               *logrus.Logger
-	config            *config.Config
-	tmpls             *webtemplate.WebTemplates
+	comparer              comparer.Comparer
+	libraryInteractor     interactor.LibraryInteractor
+	userInteractor        interactor.UserInteractor
+	disputeInteractor     interactor.DisputeInteractor
+	deckInteractor        interactor.DeckInteractor
+	historyInteractor     interactor.HistoryInteractor
+	missingWordInteractor interactor.MissingWordInteractor
+	correctionInteractor  interactor.CorrectionInteractor
+	hashDB                *datastore.HashDB
+	log                   *logrus.Logger
+	config                *config.Config
+	tmpls                 *webtemplate.WebTemplates
 }
 
 type HandleController interface {
@@ -44,17 +53,81 @@ type HandleController interface {
 	RestoreUserPasswordHandler(c echo.Context) error
 	UpdateUserHandler(c echo.Context) error
 	UpdateUserPasswordHandler(c echo.Context) error
+	UpdateUserLevelHandler(c echo.Context) error
+	UpdateUserLanguagesHandler(c echo.Context) error
 	UpdateLibraryHandler(c echo.Context) error
 	DownloadHandler(c echo.Context) error
 	GetAllUsersHandler(c echo.Context) error
 	TestHandler(c echo.Context) error
 	LearnHandler(c echo.Context) error
+	ClozeHandler(c echo.Context) error
+	PictureTestHandler(c echo.Context) error
+	AdminWordImageHandler(c echo.Context) error
+	AdminWordImageDeleteHandler(c echo.Context) error
+	AdminImagesZipHandler(c echo.Context) error
+	AdminLemmasHandler(c echo.Context) error
+	AdminLemmaCreateHandler(c echo.Context) error
+	AdminLemmaDeleteHandler(c echo.Context) error
 	ThemesHandler(c echo.Context) error
 	TestUniversalHandler(c echo.Context) error
+	AdminLibraryHandler(c echo.Context) error
+	AdminLibraryCreateHandler(c echo.Context) error
+	AdminLibraryUpdateHandler(c echo.Context) error
+	AdminLibraryDeleteHandler(c echo.Context) error
+	AdminDuplicatesHandler(c echo.Context) error
+	AdminMergeDuplicatesHandler(c echo.Context) error
+	AdminTranslationGroupsHandler(c echo.Context) error
+	AdminTranslationGroupCreateHandler(c echo.Context) error
+	AdminTranslationGroupDeleteHandler(c echo.Context) error
+	AdminTranslationGroupWordHandler(c echo.Context) error
+	AdminTranslationGroupVariantHandler(c echo.Context) error
+	TextAnalyzerHandler(c echo.Context) error
+	TextAnalyzerDeckHandler(c echo.Context) error
+	DecksHandler(c echo.Context) error
+	DeckImportHandler(c echo.Context) error
+	DeckCreateHandler(c echo.Context) error
+	DeckHandler(c echo.Context) error
+	DeckDeleteHandler(c echo.Context) error
+	CustomWordCreateHandler(c echo.Context) error
+	CustomWordDeleteHandler(c echo.Context) error
+	StudyWordHandler(c echo.Context) error
+	HistoryHandler(c echo.Context) error
+	HistorySettingsHandler(c echo.Context) error
+	HistoryClearHandler(c echo.Context) error
+	AdminMissingWordsHandler(c echo.Context) error
+	AdminMissingWordPublishHandler(c echo.Context) error
+	AdminMissingWordDismissHandler(c echo.Context) error
+	CorrectionFormHandler(c echo.Context) error
+	CorrectionCreateHandler(c echo.Context) error
+	UserCorrectionsHandler(c echo.Context) error
+	AdminCorrectionsHandler(c echo.Context) error
+	AdminApproveCorrectionHandler(c echo.Context) error
+	AdminRejectCorrectionHandler(c echo.Context) error
+	DisputeHandler(c echo.Context) error
+	UserDisputesHandler(c echo.Context) error
+	AdminDisputesHandler(c echo.Context) error
+	AdminAcceptDisputeHandler(c echo.Context) error
+	AdminRejectDisputeHandler(c echo.Context) error
+	AdminTopicsHandler(c echo.Context) error
+	AdminTopicCreateHandler(c echo.Context) error
+	AdminTopicUpdateHandler(c echo.Context) error
+	AdminTopicDeleteHandler(c echo.Context) error
+	APILoginHandler(c echo.Context) error
+	APITranslateHandler(c echo.Context) error
+	APIAutocompleteHandler(c echo.Context) error
+	APILibraryHandler(c echo.Context) error
+	APITopicsHandler(c echo.Context) error
+	APIMeHandler(c echo.Context) error
+	APIStartTestHandler(c echo.Context) error
+	APICheckTestHandler(c echo.Context) error
+	APIStartLearnHandler(c echo.Context) error
+	APICheckLearnHandler(c echo.Context) error
+	OpenAPIHandler(doc *openapi.Document) echo.HandlerFunc
+	APIDocsHandler(c echo.Context) error
 }
 
-func NewHandlersController(comparer comparer.Comparer, ui interactor.UserInteractor, li interactor.LibraryInteractor, hashDB *datastore.HashDB, log *logrus.Logger, confg *config.Config, tmpls *webtemplate.WebTemplates) HandleController {
-	return &handleController{comparer, li, ui, hashDB, log, confg, tmpls}
+func NewHandlersController(comparer comparer.Comparer, ui interactor.UserInteractor, li interactor.LibraryInteractor, di interactor.DisputeInteractor, dk interactor.DeckInteractor, hi interactor.HistoryInteractor, mw interactor.MissingWordInteractor, ci interactor.CorrectionInteractor, hashDB *datastore.HashDB, log *logrus.Logger, confg *config.Config, tmpls *webtemplate.WebTemplates) HandleController {
+	return &handleController{comparer, li, ui, di, dk, hi, mw, ci, hashDB, log, confg, tmpls}
 }
 
 func (srv *handleController) HomeHandler(c echo.Context) error {
@@ -69,60 +142,98 @@ func (srv *handleController) HomeHandler(c echo.Context) error {
 	return nil
 }
 
+// GetTranslationHandler translates a word, or glosses a sentence word by
+// word. Gloss tokens link back here with the word in the query.
 func (srv *handleController) GetTranslationHandler(c echo.Context) error {
-	if c.Request().Method == http.MethodGet {
-		err := srv.tmpls.Templates[translate].ExecuteTemplate(c.Response().Writer, translate, nil)
-		if err != nil {
-			appErr := apperrors.GetTranslationHandlerErr.AppendMessage(err)
-			srv.log.Error(appErr)
-			srv.respondErr(c.Response().Writer, appErr)
-			return appErr
-		}
+	req := &requests.TranslationRequest{}
+	bindForm(c, req)
+	wordToTranslate := strings.TrimSpace(req.Word)
+	if c.Request().Method == http.MethodPost && len(wordToTranslate) == 0 {
+		http.Redirect(c.Response().Writer, c.Request(), "/translate", http.StatusSeeOther)
+		return nil
 	}
 
-	if c.Request().Method == http.MethodPost {
-		wordToTranslate := c.FormValue("word")
-		if len(wordToTranslate) == 0 {
-			http.Redirect(c.Response().Writer, c.Request(), "/translate", http.StatusSeeOther)
-			return nil
-		}
-
-		//libService := services.NewLibraryService(srv.repoLibrary, srv.repoWords, srv.repoBackUp, srv.logger)
-		words, err := srv.libraryInteractor.GetTranslationByWord(c.Request().Context(), wordToTranslate)
-		if err != nil {
+	pair := pairOrDefault(req.Source, req.Target)
+	responseData := Rsvp{Languages: models.Languages, Pair: pair, Word: wordToTranslate, UserID: optionalUserID(c)}
+	responseData.Studied = req.Studied
+	if len(wordToTranslate) > 0 {
+		err := srv.translatePage(c, &responseData)
+		if apperrors.IsAppError(err, &apperrors.UnsupportedLanguageErr) {
+			srv.log.Info(err)
+			responseData.Unsupported = err.(*apperrors.AppError).Message
+			c.Response().WriteHeader(http.StatusUnprocessableEntity)
+		} else if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
 			srv.respondErr(c.Response().Writer, appErr)
 			return appErr
 		}
+	}
 
-		if len(words) == 0 {
-			word := &models.Library{
-				English: "There is not such world in the library",
-				Russian: "Такого слова нет в библиотеке",
-			}
+	if err := srv.tmpls.Templates[translate].ExecuteTemplate(c.Response().Writer, translate, responseData); err != nil {
+		appErr := apperrors.GetTranslationHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return appErr
+	}
 
-			words = append(words, word)
+	return nil
+}
+
+func (srv *handleController) translatePage(c echo.Context, responseData *Rsvp) error {
+	ctx := c.Request().Context()
+	if len(morphology.Tokenize(responseData.Word)) > 1 {
+		gloss, err := srv.libraryInteractor.Gloss(ctx, responseData.Word, responseData.Pair)
+		if err != nil {
+			return err
 		}
 
-		responseData := Rsvp{
-			Words:   words,
-			Word:    wordToTranslate,
-			WordRus: words[0].English,
+		responseData.Gloss = gloss
+		if err := srv.missingWordInteractor.RecordMissingGloss(ctx, responseData.Word, gloss, responseData.Pair); err != nil {
+			srv.log.Error(err)
 		}
 
-		if err := srv.tmpls.Templates[translate].ExecuteTemplate(c.Response().Writer, translate, responseData); err != nil {
-			appErr := apperrors.GetTranslationHandlerErr.AppendMessage(err)
-			srv.log.Error(appErr)
-			srv.respondErr(c.Response().Writer, appErr)
-			return appErr
+		return nil
+	}
+
+	lookup, err := srv.libraryInteractor.Translate(ctx, responseData.Word, responseData.Pair)
+	if err != nil {
+		return err
+	}
+
+	srv.recordLookup(c, responseData, lookup)
+
+	responseData.Translations = lookup.Translations
+	responseData.Lemma = lookup.Lemma
+	responseData.NotFound = len(lookup.Translations) == 0
+	if responseData.NotFound {
+		if err := srv.missingWordInteractor.RecordMissingWord(ctx, responseData.Word, responseData.Pair); err != nil {
+			srv.log.Error(err)
 		}
+
+		responseData.DidYouMean, err = srv.libraryInteractor.DidYouMean(ctx, responseData.Word, responseData.Pair)
 	}
 
-	return nil
+	return err
 }
 
-//--------------Respons---------------------------
+// recordLookup keeps the lookup in the history of a user who turned it on.
+// The page is shown even when it can't be kept.
+func (srv *handleController) recordLookup(c echo.Context, responseData *Rsvp, lookup *models.TranslationLookup) {
+	if responseData.UserID == "" {
+		return
+	}
+
+	user, err := srv.cachedUser(c, responseData.UserID)
+	if err != nil || !user.KeepHistory {
+		return
+	}
+
+	err = srv.historyInteractor.RecordLookup(c.Request().Context(), responseData.UserID, responseData.Word, responseData.Pair, lookup)
+	if err != nil {
+		srv.log.Error(err)
+	}
+}
 
 func (srv *handleController) respondErr(w http.ResponseWriter, appErr *apperrors.AppError) {
 	err := srv.tmpls.Templates[errMes].ExecuteTemplate(w, errMes, appErr)
@@ -150,16 +261,39 @@ func (srv *handleController) respondAuthorizateErr(w http.ResponseWriter, appErr
 	}
 }
 
+// QuickAnswerHandler returns the autocomplete suggestions as JSON. Limit may
+// lower the configured number of suggestions, keys shorter than the minimum
+// prefix get none.
 func (srv *handleController) QuickAnswerHandler(c echo.Context) error {
-	key := c.QueryParam("key")
-	words, err := srv.libraryInteractor.GetTranslationByPieceOfWord(c.Request().Context(), key)
+	suggestions, err := srv.suggestions(c)
 	if err != nil {
-		srv.log.Error()
-		return err
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		status := appErr.HTTPCode
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+
+		return c.JSON(status, responses.ErrorResponse{Error: appErr.Message})
 	}
 
-	c.String(http.StatusOK, words)
-	return nil
+	return c.JSON(http.StatusOK, suggestions)
+}
+
+func (srv *handleController) suggestions(c echo.Context) ([]*responses.Suggestion, error) {
+	req := &requests.SuggestRequest{}
+	bindForm(c, req)
+	key := strings.TrimSpace(req.Key)
+	if utf8.RuneCountInString(key) < srv.config.Server.QuickAnswerMinPrefix {
+		return []*responses.Suggestion{}, nil
+	}
+
+	limit := srv.config.Server.QuickAnswerLimit
+	if req.Limit > 0 && req.Limit < limit {
+		limit = req.Limit
+	}
+
+	return srv.libraryInteractor.Suggest(c.Request().Context(), key, pairOrDefault(req.Source, req.Target), limit)
 }
 
 //-------------CRUD USER------------------
@@ -176,20 +310,9 @@ func (srv *handleController) CreateUserHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodPost {
-		email := c.FormValue("email")
-		name := c.FormValue("name")
-		lastName := c.FormValue("last-name")
-		password := c.FormValue("password")
-		//role := r.FormValue("role")
-		//about := r.FormValue("about")
-
-		createUserRequest := &requests.CreateUserRequest{
-			Email:    email,
-			Name:     name,
-			LastName: lastName,
-			Password: password,
-			Role:     "user",
-		}
+		createUserRequest := &requests.CreateUserRequest{}
+		bindForm(c, createUserRequest)
+		createUserRequest.Role = "user"
 		getUserResp, err := srv.userInteractor.CreateUser(c.Request().Context(), createUserRequest)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
@@ -222,13 +345,8 @@ func (srv *handleController) LoginHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodPost {
-		email := c.Request().FormValue("email")
-		password := c.Request().FormValue("password")
-
-		loginRequest := &requests.LoginRequest{
-			Password: password,
-			Email:    email,
-		}
+		loginRequest := &requests.LoginRequest{}
+		bindForm(c, loginRequest)
 
 		getUserResp, err := srv.userInteractor.SignInUserWithJWT(c.Request().Context(), loginRequest, srv.config.Server.SecretKey, srv.config.Server.ExpirationJWTInSeconds)
 		if err != nil {
@@ -304,7 +422,7 @@ func (srv *handleController) GetUserByIdHandler(c echo.Context) error {
 		return appErr
 	}
 
-	srv.hashDB.DB[userID] = user
+	srv.hashDB.Set(userID, user)
 	err = srv.tmpls.Templates[userInfo].ExecuteTemplate(c.Response().Writer, userInfo, user)
 	if err != nil {
 		appErr := apperrors.GetUserByIdHandlerErr.AppendMessage(err)
@@ -328,9 +446,10 @@ func (srv *handleController) RestoreUserPasswordHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodPost {
-		email := c.Request().FormValue("email")
+		req := &requests.RestorePasswordRequest{}
+		bindForm(c, req)
 
-		err := srv.userInteractor.RestoreUserPassword(c.Request().Context(), email)
+		err := srv.userInteractor.RestoreUserPassword(c.Request().Context(), req.Email)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
@@ -353,8 +472,15 @@ func (srv *handleController) UpdateUserHandler(c echo.Context) error {
 		return appErr
 	}
 
+	user, err := srv.cachedUser(c, userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return appErr
+	}
+
 	if c.Request().Method == http.MethodGet {
-		user := srv.hashDB.DB[userID]
 		err := srv.tmpls.Templates[updateUser].ExecuteTemplate(c.Response().Writer, updateUser, user)
 		if err != nil {
 			appErr := apperrors.UpdateUserHandlerErr.AppendMessage(err)
@@ -365,22 +491,9 @@ func (srv *handleController) UpdateUserHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodPost {
-		email := c.Request().FormValue("email")
-		name := c.Request().FormValue("name")
-		lastName := c.Request().FormValue("last-name")
-		password := c.Request().FormValue("password")
-		role := c.Request().FormValue("role")
-		//about := r.FormValue("about")
+		createUserRequest := &requests.CreateUserRequest{}
+		bindForm(c, createUserRequest)
 
-		createUserRequest := &requests.CreateUserRequest{
-			Email:    email,
-			Name:     name,
-			LastName: lastName,
-			Password: password,
-			Role:     role,
-		}
-
-		user := srv.hashDB.DB[userID]
 		err := srv.userInteractor.UpdateUserById(c.Request().Context(), user, createUserRequest)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
@@ -389,6 +502,8 @@ func (srv *handleController) UpdateUserHandler(c echo.Context) error {
 			return nil
 		}
 
+		srv.hashDB.Delete(userID)
+
 		if err := srv.tmpls.Templates[registration].ExecuteTemplate(c.Response().Writer, registration, createUserRequest); err != nil {
 			appErr := apperrors.UpdateUserHandlerErr.AppendMessage(err)
 			srv.log.Error(appErr)
@@ -400,6 +515,83 @@ func (srv *handleController) UpdateUserHandler(c echo.Context) error {
 	return nil
 }
 
+func (srv *handleController) UpdateUserLevelHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.UpdateUserLevelHandlerErr.AppendMessage("UserIdErr")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.UserLevelRequest{}
+	bindForm(c, req)
+	err := srv.userInteractor.UpdateUserLevel(c.Request().Context(), userID, req.Level)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.hashDB.Delete(userID)
+
+	http.Redirect(c.Response().Writer, c.Request(), "/user-info", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) UpdateUserLanguagesHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.UpdateUserLanguagesHandlerErr.AppendMessage("UserIdErr")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.LanguagePairRequest{}
+	bindForm(c, req)
+	pair := models.LanguagePair{Source: req.Source, Target: req.Target}
+	err := srv.userInteractor.UpdateUserLanguages(c.Request().Context(), userID, pair)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.hashDB.Delete(userID)
+
+	http.Redirect(c.Response().Writer, c.Request(), "/user-info", http.StatusSeeOther)
+	return nil
+}
+
+// languagePair returns the pair the user studies.
+func (srv *handleController) languagePair(c echo.Context, userID string) models.LanguagePair {
+	user, err := srv.cachedUser(c, userID)
+	if err != nil {
+		srv.log.Error(err)
+		return models.DefaultLanguagePair
+	}
+
+	return user.LanguagePair()
+}
+
+// cachedUser returns the cached user, loading it when there is none.
+func (srv *handleController) cachedUser(c echo.Context, userID string) (*models.User, error) {
+	if user, ok := srv.hashDB.Get(userID); ok {
+		return user, nil
+	}
+
+	user, err := srv.userInteractor.GetUserById(c.Request().Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	srv.hashDB.Set(userID, user)
+	return user, nil
+}
+
 func (srv *handleController) UpdateUserPasswordHandler(c echo.Context) error {
 	userID, _, ok := srv.getIdANdRoleFromRequest(c)
 	if !ok {
@@ -409,8 +601,15 @@ func (srv *handleController) UpdateUserPasswordHandler(c echo.Context) error {
 		return nil
 	}
 
+	user, err := srv.cachedUser(c, userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
 	if c.Request().Method == http.MethodGet {
-		user := srv.hashDB.DB[userID]
 		err := srv.tmpls.Templates[updateUserPassword].ExecuteTemplate(c.Response().Writer, updateUserPassword, user)
 		if err != nil {
 			appErr := apperrors.UpdateUserPasswordHandlerErr.AppendMessage(err)
@@ -421,12 +620,10 @@ func (srv *handleController) UpdateUserPasswordHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodPost {
-		oldPass := c.Request().FormValue("old_password")
-		newPass := c.Request().FormValue("new_password")
-		newPassSecond := c.Request().FormValue("new_password_second")
+		req := &requests.UpdatePasswordRequest{}
+		bindForm(c, req)
 
-		user := srv.hashDB.DB[userID]
-		err := srv.userInteractor.UpdateUserPasswordById(c.Request().Context(), user, oldPass, newPass, newPassSecond)
+		err := srv.userInteractor.UpdateUserPasswordById(c.Request().Context(), user, req.OldPassword, req.NewPassword, req.NewPasswordSecond)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
@@ -434,11 +631,13 @@ func (srv *handleController) UpdateUserPasswordHandler(c echo.Context) error {
 			return nil
 		}
 
+		srv.hashDB.Delete(userID)
+
 		createUserRequest := &requests.CreateUserRequest{
 			Email:    user.Email,
 			Name:     user.Name,
 			LastName: user.LastName,
-			Password: newPass,
+			Password: req.NewPassword,
 			Role:     user.Role,
 		}
 
@@ -465,8 +664,7 @@ func (srv *handleController) TestHandler(c echo.Context) error {
 	}
 
 	if c.Request().Method == http.MethodGet {
-		getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
-		words, err := srv.userInteractor.GetWordsByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+		pageData, err := srv.startTest(c, userID)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
@@ -474,13 +672,6 @@ func (srv *handleController) TestHandler(c echo.Context) error {
 			return nil
 		}
 
-		pageData := &models.TestPageData{
-			Words:      words,
-			TestPassed: false,
-		}
-
-		comparer.HashTableWords[userID] = pageData
-
 		err = srv.tmpls.Templates[test].ExecuteTemplate(c.Response().Writer, test, pageData)
 		if err != nil {
 			appErr := apperrors.TestHandlerErr.AppendMessage(err)
@@ -507,7 +698,8 @@ func (srv *handleController) TestHandler(c echo.Context) error {
 			return nil
 		}
 
-		err = srv.tmpls.Templates[test].ExecuteTemplate(c.Response().Writer, test, comparer.HashTableWords[userID])
+		pageData, _ := comparer.HashTableWords.Get(userID)
+		err = srv.tmpls.Templates[test].ExecuteTemplate(c.Response().Writer, test, pageData)
 		if err != nil {
 			appErr := apperrors.TestHandlerErr.AppendMessage(err)
 			srv.log.Error(appErr)
@@ -528,10 +720,8 @@ func (srv *handleController) LearnHandler(c echo.Context) error {
 		return nil
 	}
 
-	getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
-
 	if c.Request().Method == http.MethodGet {
-		words, err := srv.userInteractor.GetLearnByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+		pageData, err := srv.startLearn(c, userID)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
@@ -539,11 +729,6 @@ func (srv *handleController) LearnHandler(c echo.Context) error {
 			return nil
 		}
 
-		pageData := &models.TestPageData{
-			Words: words,
-		}
-
-		comparer.HashTableWordsLearn[userID] = pageData
 		err = srv.tmpls.Templates[learn].ExecuteTemplate(c.Response().Writer, learn, pageData)
 		if err != nil {
 			appErr := apperrors.LearnHandlerErr.AppendMessage("User ID Err")
@@ -570,7 +755,8 @@ func (srv *handleController) LearnHandler(c echo.Context) error {
 			return nil
 		}
 
-		err = srv.tmpls.Templates[learn].ExecuteTemplate(c.Response().Writer, learn, comparer.HashTableWordsLearn[userID])
+		pageData, _ := comparer.HashTableWordsLearn.Get(userID)
+		err = srv.tmpls.Templates[learn].ExecuteTemplate(c.Response().Writer, learn, pageData)
 		if err != nil {
 			appErr := apperrors.LearnHandlerErr
 			srv.log.Error(appErr)
@@ -582,11 +768,71 @@ func (srv *handleController) LearnHandler(c echo.Context) error {
 	return nil
 }
 
+// startTest picks five words of the user's library, or of the deck in the
+// query, and keeps them as the user's test.
+func (srv *handleController) startTest(c echo.Context, userID string) (*models.TestPageData, error) {
+	pair := srv.languagePair(c, userID)
+	var deck *models.Deck
+	var words []*models.Word
+	var err error
+	if deckID := deckQuery(c); deckID != "" {
+		deck, words, err = srv.deckInteractor.GetDeckWords(c.Request().Context(), userID, deckID, pair, false, 5)
+	} else {
+		getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
+		words, err = srv.userInteractor.GetWordsByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	localized, untranslated := srv.libraryInteractor.LocalizeWords(words, pair)
+	pageData := &models.TestPageData{
+		Pair:         pair,
+		Deck:         deck,
+		Words:        localized,
+		Untranslated: untranslated,
+		TestPassed:   false,
+	}
+
+	comparer.HashTableWords.Set(userID, pageData)
+	return pageData, nil
+}
+
+// startLearn is startTest for the words the user learns.
+func (srv *handleController) startLearn(c echo.Context, userID string) (*models.TestPageData, error) {
+	pair := srv.languagePair(c, userID)
+	var deck *models.Deck
+	var words []*models.Word
+	var err error
+	if deckID := deckQuery(c); deckID != "" {
+		deck, words, err = srv.deckInteractor.GetDeckWords(c.Request().Context(), userID, deckID, pair, true, 5)
+	} else {
+		getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
+		words, err = srv.userInteractor.GetLearnByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	localized, untranslated := srv.libraryInteractor.LocalizeWords(words, pair)
+	pageData := &models.TestPageData{
+		Pair:         pair,
+		Deck:         deck,
+		Words:        localized,
+		Untranslated: untranslated,
+	}
+
+	comparer.HashTableWordsLearn.Set(userID, pageData)
+	return pageData, nil
+}
+
 //------------------thematic tests--------------------
 
 func (srv *handleController) ThemesHandler(c echo.Context) error {
 
-	topics, err := srv.libraryInteractor.GetAllTopics()
+	topics, err := srv.libraryInteractor.GetTopicTree(c.Request().Context())
 	if err != nil {
 		appErr := apperrors.ThemesHandlerErr.AppendMessage(err)
 		srv.log.Error(err)
@@ -594,26 +840,7 @@ func (srv *handleController) ThemesHandler(c echo.Context) error {
 		return nil
 	}
 
-	topicsAndBytes := []struct {
-		TopStr       string
-		TopUnderLine string
-	}{}
-
-	for _, top := range topics {
-		underLine := strings.ReplaceAll(top, " ", "_")
-
-		biStr := struct {
-			TopStr       string
-			TopUnderLine string
-		}{
-			TopStr:       top,
-			TopUnderLine: underLine,
-		}
-
-		topicsAndBytes = append(topicsAndBytes, biStr)
-	}
-
-	err = srv.tmpls.Templates[testThematic].ExecuteTemplate(c.Response().Writer, testThematic, topicsAndBytes)
+	err = srv.tmpls.Templates[testThematic].ExecuteTemplate(c.Response().Writer, testThematic, topics)
 	if err != nil {
 		appErr := apperrors.ThemesHandlerErr.AppendMessage(err)
 		srv.log.Error(err)
@@ -624,7 +851,6 @@ func (srv *handleController) ThemesHandler(c echo.Context) error {
 	return nil
 }
 
-// ---------------------DOESN'T WORK------------------
 func (srv *handleController) TestUniversalHandler(c echo.Context) error {
 	userID, _, ok := srv.getIdANdRoleFromRequest(c)
 	if !ok {
@@ -635,11 +861,16 @@ func (srv *handleController) TestUniversalHandler(c echo.Context) error {
 	}
 
 	getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
-	//vars := mux.Vars(c.Request())
-	topic := c.Param("theme")
-	topicGet := strings.ReplaceAll(topic, "_", " ")
+	topic, topicIDs, err := srv.libraryInteractor.GetTopicWithSubtopics(c.Request().Context(), c.Param("slug"))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
 	if c.Request().Method == http.MethodGet {
-		words, err := srv.userInteractor.GetWordsByUserIdAndLimitAndTopic(c.Request().Context(), getWordsByUsIdAndLimitRequest, topicGet)
+		words, err := srv.userInteractor.GetWordsByUserIdAndLimitAndTopic(c.Request().Context(), getWordsByUsIdAndLimitRequest, topicIDs)
 		if err != nil {
 			appErr := err.(*apperrors.AppError)
 			srv.log.Error(appErr)
@@ -647,14 +878,19 @@ func (srv *handleController) TestUniversalHandler(c echo.Context) error {
 			return nil
 		}
 
+		pair := srv.languagePair(c, userID)
+		localized, untranslated := srv.libraryInteractor.LocalizeWords(words, pair)
 		pageData := &models.TestPageData{
-			Topic: topic,
-			Words: words,
+			Topic:        topic.Slug,
+			TopicTitle:   topic.Title(),
+			Pair:         pair,
+			Words:        localized,
+			Untranslated: untranslated,
 			//Result: results,
 			TestPassed: false,
 		}
 
-		comparer.HashTableWords[userID] = pageData
+		comparer.HashTableWords.Set(userID, pageData)
 		err = srv.tmpls.Templates[testThematicHandler].ExecuteTemplate(c.Response().Writer, testThematicHandler, pageData)
 		if err != nil {
 			appErr := apperrors.TestUniversalHandlerErr.AppendMessage(err)
@@ -681,7 +917,8 @@ func (srv *handleController) TestUniversalHandler(c echo.Context) error {
 			return nil
 		}
 
-		err = srv.tmpls.Templates[testThematicHandler].ExecuteTemplate(c.Response().Writer, testThematicHandler, comparer.HashTableWords[userID])
+		pageData, _ := comparer.HashTableWords.Get(userID)
+		err = srv.tmpls.Templates[testThematicHandler].ExecuteTemplate(c.Response().Writer, testThematicHandler, pageData)
 		if err != nil {
 			appErr := apperrors.TestUniversalHandlerErr.AppendMessage(err)
 			srv.log.Error(appErr)
@@ -762,7 +999,7 @@ func (srv *handleController) DownloadHandler(c echo.Context) error {
 		srv.respondAuthorizateErr(c.Response().Writer, appErr)
 		return nil
 	}
-	file, err := srv.libraryInteractor.DownloadXLXFromDb()
+	file, err := srv.libraryInteractor.DownloadXLXFromDb(c.Request().Context())
 	if err != nil {
 		appErr := err.(*apperrors.AppError)
 		srv.log.Error(appErr)
@@ -816,6 +1053,12 @@ func (srv *handleController) GetAllUsersHandler(c echo.Context) error {
 	return nil
 }
 
+// optionalUserID returns the user of a page open to guests, empty for a guest.
+func optionalUserID(c echo.Context) string {
+	id, _ := c.Get(contextKeyID).(string)
+	return id
+}
+
 func (srv *handleController) getIdANdRoleFromRequest(c echo.Context) (string, string, bool) {
 	// err := c.Get("err").(string)
 	// if err != "" {
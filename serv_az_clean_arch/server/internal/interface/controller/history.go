@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+type historyPage struct {
+	KeepHistory bool
+	History     []*models.LookupHistory
+}
+
+//------------Translation history----------------------
+
+// StudyWordHandler puts a word found on the translate page into the user's
+// learn queue and shows the translation again.
+func (srv *handleController) StudyWordHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.StudyWordHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.StudyRequest{}
+	bindForm(c, req)
+	if err := srv.userInteractor.AddWordToLearn(c.Request().Context(), userID, req.WordID); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	query := url.Values{}
+	query.Set("word", req.Word)
+	query.Set("source", req.Source)
+	query.Set("target", req.Target)
+	query.Set("studied", "1")
+	http.Redirect(c.Response().Writer, c.Request(), "/translate?"+query.Encode(), http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) HistoryHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.HistoryHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	user, err := srv.cachedUser(c, userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := historyPage{KeepHistory: user.KeepHistory}
+	page.History, err = srv.historyInteractor.GetHistory(c.Request().Context(), userID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := srv.tmpls.Templates[history].ExecuteTemplate(c.Response().Writer, history, page); err != nil {
+		appErr := apperrors.HistoryHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+// HistorySettingsHandler turns the lookup history on or off.
+func (srv *handleController) HistorySettingsHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.HistoryHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.HistorySettingsRequest{}
+	bindForm(c, req)
+	if err := srv.historyInteractor.SetKeepHistory(c.Request().Context(), userID, req.Keep); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.hashDB.Delete(userID)
+
+	http.Redirect(c.Response().Writer, c.Request(), "/history", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) HistoryClearHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.HistoryHandlerErr.AppendMessage("there is no user in request")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if err := srv.historyInteractor.ClearHistory(c.Request().Context(), userID); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/history", http.StatusSeeOther)
+	return nil
+}
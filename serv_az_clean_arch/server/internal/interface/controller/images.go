@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/comparer"
+
+	"github.com/labstack/echo"
+)
+
+// pictureDeckCandidates is how many deck words are looked through to find
+// ones that have pictures.
+const (
+	maxImageUpload        = 10 << 20
+	maxZipUpload          = 200 << 20
+	pictureDeckCandidates = 50
+)
+
+//------------Word images role admin----------------------
+
+func (srv *handleController) AdminWordImageHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response().Writer, c.Request().Body, maxImageUpload)
+	file, _, err := c.Request().FormFile("image")
+	if err != nil {
+		appErr := apperrors.AdminImagesHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		appErr := apperrors.AdminImagesHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.AdminWordRequest{}
+	bindForm(c, req)
+	err = srv.libraryInteractor.SetWordImage(c.Request().Context(), req.ID, data)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+func (srv *handleController) AdminWordImageDeleteHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.AdminWordRequest{}
+	bindForm(c, req)
+	err := srv.libraryInteractor.DeleteWordImage(c.Request().Context(), req.ID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+func (srv *handleController) AdminImagesZipHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response().Writer, c.Request().Body, maxZipUpload)
+	file, header, err := c.Request().FormFile("archive")
+	if err != nil {
+		appErr := apperrors.AdminImagesHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	defer file.Close()
+
+	imported, err := srv.libraryInteractor.ImportImagesZIP(c.Request().Context(), file, header.Size)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.log.Infof("[%v] word images imported", imported)
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+// ----------------picture test------------------------
+func (srv *handleController) PictureTestHandler(c echo.Context) error {
+	userID, _, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok {
+		appErr := apperrors.PictureTestHandlerErr.AppendMessage("UserIdErr")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	if c.Request().Method == http.MethodGet {
+		pair := srv.languagePair(c, userID)
+		var deck *models.Deck
+		var words []*models.Word
+		var err error
+		if deckID := deckQuery(c); deckID != "" {
+			deck, words, err = srv.deckInteractor.GetDeckWords(c.Request().Context(), userID, deckID, pair, false, pictureDeckCandidates)
+			words = wordsWithImage(words, 5)
+		} else {
+			getWordsByUsIdAndLimitRequest := &requests.GetWordsByUsIdAndLimitRequest{ID: userID, Limit: "5"}
+			words, err = srv.userInteractor.GetPictureWordsByUsIdAndLimit(c.Request().Context(), getWordsByUsIdAndLimitRequest)
+		}
+
+		if err != nil {
+			appErr := err.(*apperrors.AppError)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		localized, untranslated := srv.libraryInteractor.LocalizeWords(words, pair)
+		pageData := &models.TestPageData{
+			Pair:         pair,
+			Deck:         deck,
+			Words:        localized,
+			Untranslated: untranslated,
+			TestPassed:   false,
+		}
+
+		comparer.HashTablePictures.Set(userID, pageData)
+
+		err = srv.tmpls.Templates[testPictures].ExecuteTemplate(c.Response().Writer, testPictures, pageData)
+		if err != nil {
+			appErr := apperrors.PictureTestHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	if c.Request().Method == http.MethodPost {
+		err := c.Request().ParseForm()
+		if err != nil {
+			appErr := apperrors.PictureTestHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		err = srv.comparer.ComparePictureWords(c.Request(), userID)
+		if err != nil {
+			appErr := apperrors.PictureTestHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+
+		pageData, _ := comparer.HashTablePictures.Get(userID)
+		err = srv.tmpls.Templates[testPictures].ExecuteTemplate(c.Response().Writer, testPictures, pageData)
+		if err != nil {
+			appErr := apperrors.PictureTestHandlerErr.AppendMessage(err)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func wordsWithImage(words []*models.Word, limit int) []*models.Word {
+	pictures := []*models.Word{}
+	for _, word := range words {
+		if len(pictures) >= limit {
+			break
+		}
+
+		if word.Image != "" {
+			pictures = append(pictures, word)
+		}
+	}
+
+	return pictures
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+//------------Lemmas role admin----------------------
+
+func (srv *handleController) AdminLemmasHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	counts, err := srv.libraryInteractor.CountLemmas(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.LemmasRequest{}
+	bindForm(c, req)
+	pageData := &models.LemmasPageData{
+		WordID:    req.WordID,
+		Counts:    counts,
+		Languages: models.Languages,
+	}
+
+	if pageData.WordID != "" {
+		pageData.Lemmas, err = srv.libraryInteractor.GetWordLemmas(c.Request().Context(), pageData.WordID)
+		if err != nil {
+			appErr := err.(*apperrors.AppError)
+			srv.log.Error(appErr)
+			srv.respondErr(c.Response().Writer, appErr)
+			return nil
+		}
+	}
+
+	err = srv.tmpls.Templates[adminLemmas].ExecuteTemplate(c.Response().Writer, adminLemmas, pageData)
+	if err != nil {
+		appErr := apperrors.AdminLemmasHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminLemmaCreateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.LemmaRequest{}
+	bindForm(c, req)
+
+	err := srv.libraryInteractor.AddLemma(c.Request().Context(), req)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLemmas(c, req.WordID)
+	return nil
+}
+
+func (srv *handleController) AdminLemmaDeleteHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.LemmaRequest{}
+	bindForm(c, req)
+	err := srv.libraryInteractor.DeleteLemma(c.Request().Context(), req.ID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLemmas(c, req.WordID)
+	return nil
+}
+
+func (srv *handleController) redirectToAdminLemmas(c echo.Context, wordID string) {
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/lemmas?word="+url.QueryEscape(wordID), http.StatusSeeOther)
+}
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+//------------Library CRUD role admin----------------------
+
+func (srv *handleController) AdminLibraryHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	filter := &requests.LibraryFilterRequest{}
+	bindForm(c, filter)
+
+	words, total, err := srv.libraryInteractor.GetLibraryPage(c.Request().Context(), filter)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	themes, err := srv.libraryInteractor.GetAllTopics()
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	partsOfSpeech, err := srv.libraryInteractor.GetAllPartsOfSpeech()
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	pages := int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize))
+	pageData := &models.LibraryPageData{
+		Words:         words,
+		Themes:        themes,
+		PartsOfSpeech: partsOfSpeech,
+		Theme:         filter.Theme,
+		PartOfSpeech:  filter.PartsOfSpeech,
+		Levels:        models.CEFRLevels,
+		Registers:     models.Registers,
+		Level:         filter.Level,
+		Text:          filter.Text,
+		FilterQuery:   libraryFilterQuery(filter),
+		Page:          filter.Page,
+		Pages:         pages,
+		Total:         total,
+	}
+
+	if filter.Page > 1 {
+		pageData.PrevPage = filter.Page - 1
+	}
+
+	if filter.Page < pages {
+		pageData.NextPage = filter.Page + 1
+	}
+
+	err = srv.tmpls.Templates[adminLibrary].ExecuteTemplate(c.Response().Writer, adminLibrary, pageData)
+	if err != nil {
+		appErr := apperrors.AdminLibraryHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminLibraryCreateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	_, err := srv.libraryInteractor.CreateLibraryWord(c.Request().Context(), libraryWordRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+func (srv *handleController) AdminLibraryUpdateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	authorID, _, _ := srv.getIdANdRoleFromRequest(c)
+	err := srv.libraryInteractor.UpdateLibraryWord(c.Request().Context(), authorID, libraryWordRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+func (srv *handleController) AdminLibraryDeleteHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.AdminWordRequest{}
+	bindForm(c, req)
+	err := srv.libraryInteractor.DeleteLibraryWord(c.Request().Context(), req.ID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	srv.redirectToAdminLibrary(c)
+	return nil
+}
+
+func (srv *handleController) AdminDuplicatesHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	groups, err := srv.libraryInteractor.FindDuplicates(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.tmpls.Templates[adminDuplicates].ExecuteTemplate(c.Response().Writer, adminDuplicates, groups)
+	if err != nil {
+		appErr := apperrors.AdminDuplicatesHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminMergeDuplicatesHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	if err := c.Request().ParseForm(); err != nil {
+		appErr := apperrors.AdminDuplicatesHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	req := &requests.MergeWordsRequest{}
+	bindForm(c, req)
+	err := srv.libraryInteractor.MergeDuplicates(c.Request().Context(), req.Canonical, req.Duplicates)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/library/duplicates", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) redirectToAdminLibrary(c echo.Context) {
+	back, err := url.ParseQuery(c.FormValue("back"))
+	if err != nil {
+		back = url.Values{}
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/library?"+back.Encode(), http.StatusSeeOther)
+}
+
+func (srv *handleController) checkAdmin(c echo.Context) bool {
+	_, role, ok := srv.getIdANdRoleFromRequest(c)
+	if !ok || role != "admin" {
+		appErr := apperrors.AdminAccessErr.AppendMessage("ask for help in contacts")
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return false
+	}
+
+	return true
+}
+
+func libraryWordRequestFromForm(c echo.Context) *requests.LibraryWordRequest {
+	req := &requests.LibraryWordRequest{}
+	bindForm(c, req)
+	return req
+}
+
+func libraryFilterQuery(filter *requests.LibraryFilterRequest) string {
+	query := url.Values{}
+	if filter.Text != "" {
+		query.Set("text", filter.Text)
+	}
+
+	if filter.Theme != "" {
+		query.Set("theme", filter.Theme)
+	}
+
+	if filter.PartsOfSpeech != "" {
+		query.Set("part_of_speech", filter.PartsOfSpeech)
+	}
+
+	if filter.Level != "" {
+		query.Set("level", filter.Level)
+	}
+
+	return query.Encode()
+}
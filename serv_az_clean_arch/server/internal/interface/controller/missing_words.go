@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+type missingWordsPage struct {
+	Words  []*models.MissingWord
+	Themes []string
+}
+
+//------------Missing words----------------------
+
+// AdminMissingWordsHandler shows the words looked up on the translate page
+// that the library doesn't have, the most looked up first.
+func (srv *handleController) AdminMissingWordsHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	words, err := srv.missingWordInteractor.GetMissingWords(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	themes, err := srv.libraryInteractor.GetAllTopics()
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	page := missingWordsPage{Words: words, Themes: themes}
+	if err := srv.tmpls.Templates[adminMissingWords].ExecuteTemplate(c.Response().Writer, adminMissingWords, page); err != nil {
+		appErr := apperrors.AdminMissingWordsHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminMissingWordPublishHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.MissingWordRequest{}
+	bindForm(c, req)
+
+	if err := srv.missingWordInteractor.PublishMissingWord(c.Request().Context(), req); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/missing-words", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) AdminMissingWordDismissHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.IDRequest{}
+	bindForm(c, req)
+	if err := srv.missingWordInteractor.DismissMissingWord(c.Request().Context(), req.ID); err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/missing-words", http.StatusSeeOther)
+	return nil
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/infrastructure/openapi"
+
+	"github.com/labstack/echo"
+)
+
+//------------API docs----------------------
+
+// OpenAPIHandler answers with the document router.NewRouter built from the
+// registered routes.
+func (srv *handleController) OpenAPIHandler(doc *openapi.Document) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc)
+	}
+}
+
+func (srv *handleController) APIDocsHandler(c echo.Context) error {
+	if err := srv.tmpls.Templates[apiDocs].ExecuteTemplate(c.Response().Writer, apiDocs, "/api/openapi.json"); err != nil {
+		appErr := apperrors.APIDocsHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
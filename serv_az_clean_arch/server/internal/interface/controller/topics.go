@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+//------------Topics role admin----------------------
+
+func (srv *handleController) AdminTopicsHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	topics, err := srv.libraryInteractor.GetTopicTree(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.tmpls.Templates[adminTopics].ExecuteTemplate(c.Response().Writer, adminTopics, topics)
+	if err != nil {
+		appErr := apperrors.AdminTopicsHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminTopicCreateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	err := srv.libraryInteractor.CreateTopic(c.Request().Context(), topicRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/topics", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) AdminTopicUpdateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	err := srv.libraryInteractor.UpdateTopic(c.Request().Context(), topicRequestFromForm(c))
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/topics", http.StatusSeeOther)
+	return nil
+}
+
+func (srv *handleController) AdminTopicDeleteHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := &requests.IDRequest{}
+	bindForm(c, req)
+	err := srv.libraryInteractor.DeleteTopic(c.Request().Context(), req.ID)
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/topics", http.StatusSeeOther)
+	return nil
+}
+
+func topicRequestFromForm(c echo.Context) *requests.TopicRequest {
+	req := &requests.TopicRequest{}
+	bindForm(c, req)
+	return req
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"net/http"
+	"server/internal/apperrors"
+	"server/internal/domain/requests"
+
+	"github.com/labstack/echo"
+)
+
+//------------Translation groups role admin----------------------
+
+func (srv *handleController) AdminTranslationGroupsHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	groups, err := srv.libraryInteractor.GetTranslationGroups(c.Request().Context())
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	err = srv.tmpls.Templates[adminGroups].ExecuteTemplate(c.Response().Writer, adminGroups, groups)
+	if err != nil {
+		appErr := apperrors.AdminTranslationGroupsHandlerErr.AppendMessage(err)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	return nil
+}
+
+func (srv *handleController) AdminTranslationGroupCreateHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	err := srv.libraryInteractor.CreateTranslationGroup(c.Request().Context(), translationGroupRequestFromForm(c))
+	return srv.redirectToTranslationGroups(c, err)
+}
+
+func (srv *handleController) AdminTranslationGroupDeleteHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	err := srv.libraryInteractor.DeleteTranslationGroup(c.Request().Context(), translationGroupRequestFromForm(c))
+	return srv.redirectToTranslationGroups(c, err)
+}
+
+func (srv *handleController) AdminTranslationGroupWordHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := translationGroupRequestFromForm(c)
+	var err error
+	if req.Action == "remove" {
+		err = srv.libraryInteractor.RemoveWordFromTranslationGroup(c.Request().Context(), req)
+	} else {
+		err = srv.libraryInteractor.AddWordToTranslationGroup(c.Request().Context(), req)
+	}
+
+	return srv.redirectToTranslationGroups(c, err)
+}
+
+func (srv *handleController) AdminTranslationGroupVariantHandler(c echo.Context) error {
+	if !srv.checkAdmin(c) {
+		return nil
+	}
+
+	req := translationGroupRequestFromForm(c)
+	var err error
+	if req.Action == "remove" {
+		err = srv.libraryInteractor.DeleteTranslationVariant(c.Request().Context(), req.VariantID)
+	} else {
+		err = srv.libraryInteractor.AddTranslationVariant(c.Request().Context(), req)
+	}
+
+	return srv.redirectToTranslationGroups(c, err)
+}
+
+func (srv *handleController) redirectToTranslationGroups(c echo.Context, err error) error {
+	if err != nil {
+		appErr := err.(*apperrors.AppError)
+		srv.log.Error(appErr)
+		srv.respondErr(c.Response().Writer, appErr)
+		return nil
+	}
+
+	http.Redirect(c.Response().Writer, c.Request(), "/admin/translation-groups", http.StatusSeeOther)
+	return nil
+}
+
+func translationGroupRequestFromForm(c echo.Context) *requests.TranslationGroupRequest {
+	req := &requests.TranslationGroupRequest{}
+	bindForm(c, req)
+	return req
+}
@@ -5,9 +5,18 @@ import (
 )
 
 type Rsvp struct {
-	Words    []*models.Library
-	WordRus  string
-	WordEng  string
-	Word     string
-	Quantity int
+	Translations []*models.Translation
+	Lemma        string
+	Languages    []*models.Language
+	Pair         models.LanguagePair
+	NotFound     bool
+	Unsupported  string
+	DidYouMean   []string
+	Gloss        []*models.GlossToken
+	WordRus      string
+	WordEng      string
+	Word         string
+	Quantity     int
+	UserID       string
+	Studied      bool
 }
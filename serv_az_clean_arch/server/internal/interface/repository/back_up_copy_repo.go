@@ -7,6 +7,7 @@ import (
 	"server/internal/usercase/repository"
 
 	"strconv"
+	"strings"
 	"unicode"
 
 	"github.com/sirupsen/logrus"
@@ -36,6 +37,10 @@ func (tr *backUpCopyRepo) GetAllWordsFromBackUpXlsx() ([]*models.Library, error)
 			break
 		}
 
+		if models.IsExtraSheet(sheet.Name) {
+			continue
+		}
+
 		for _, row := range sheet.Rows {
 			if len(row.Cells) == 0 {
 				continue
@@ -59,6 +64,23 @@ func (tr *backUpCopyRepo) GetAllWordsFromBackUpXlsx() ([]*models.Library, error)
 				PartsOfSpeech: row.Cells[6].String(),
 			}
 
+			if len(row.Cells) > 7 {
+				word.TranslationGroupName = row.Cells[7].String()
+			}
+
+			if len(row.Cells) > 9 {
+				word.Level = strings.ToUpper(strings.TrimSpace(row.Cells[8].String()))
+				word.Frequency, _ = strconv.Atoi(strings.TrimSpace(row.Cells[9].String()))
+			}
+
+			if len(row.Cells) > 14 {
+				word.Transcription = strings.TrimSpace(row.Cells[10].String())
+				word.RussianStressed = capitalizeFirstRune(strings.TrimSpace(row.Cells[11].String()))
+				word.Forms = strings.TrimSpace(row.Cells[12].String())
+				word.Register = strings.ToLower(strings.TrimSpace(row.Cells[13].String()))
+				word.UsageNote = strings.TrimSpace(row.Cells[14].String())
+			}
+
 			wordNew = append(wordNew, word)
 
 			tr.log.Info(word.English)
@@ -69,7 +91,7 @@ func (tr *backUpCopyRepo) GetAllWordsFromBackUpXlsx() ([]*models.Library, error)
 	return wordNew, nil
 }
 
-func (tr *backUpCopyRepo) SaveWordsAsXLSX(words []*models.Library) error {
+func (tr *backUpCopyRepo) SaveWordsAsXLSX(words []*models.Library, phrases []*models.Phrase, lemmas []*models.Lemma) error {
 	file := xlsx.NewFile()
 
 	sheet, err := file.AddSheet("Sheet1")
@@ -95,10 +117,73 @@ func (tr *backUpCopyRepo) SaveWordsAsXLSX(words []*models.Library) error {
 		cell.Value = word.Theme
 		cell = row.AddCell()
 		cell.Value = word.PartsOfSpeech
+		cell = row.AddCell()
+		cell.Value = word.TranslationGroupName
+		cell = row.AddCell()
+		cell.Value = word.Level
+		cell = row.AddCell()
+		if word.Frequency > 0 {
+			cell.SetInt(word.Frequency)
+		}
+		cell = row.AddCell()
+		cell.Value = word.Transcription
+		cell = row.AddCell()
+		cell.Value = word.RussianStressed
+		cell = row.AddCell()
+		cell.Value = word.Forms
+		cell = row.AddCell()
+		cell.Value = word.Register
+		cell = row.AddCell()
+		cell.Value = word.UsageNote
 		//cell = row.AddCell()
 		//cell.SetInt(word.RightAnswer)
 	}
 
+	if len(phrases) > 0 {
+		sheet, err := file.AddSheet(models.SentencesSheet)
+		if err != nil {
+			appErr := apperrors.SaveWordsAsXLSXErr.AppendMessage(err)
+			tr.log.Error(err)
+			return appErr
+		}
+
+		for _, phrase := range phrases {
+			wordIDs := make([]string, 0, len(phrase.Libraries))
+			for _, library := range phrase.Libraries {
+				wordIDs = append(wordIDs, strconv.Itoa(library.ID))
+			}
+
+			row := sheet.AddRow()
+			cell := row.AddCell()
+			cell.SetInt(phrase.ID)
+			cell = row.AddCell()
+			cell.Value = phrase.English
+			cell = row.AddCell()
+			cell.Value = phrase.Russian
+			cell = row.AddCell()
+			cell.Value = strings.Join(wordIDs, ", ")
+		}
+	}
+
+	if len(lemmas) > 0 {
+		sheet, err := file.AddSheet(models.LemmasSheet)
+		if err != nil {
+			appErr := apperrors.SaveWordsAsXLSXErr.AppendMessage(err)
+			tr.log.Error(err)
+			return appErr
+		}
+
+		for _, lemma := range lemmas {
+			row := sheet.AddRow()
+			cell := row.AddCell()
+			cell.SetInt(lemma.LibraryID)
+			cell = row.AddCell()
+			cell.Value = lemma.Language
+			cell = row.AddCell()
+			cell.Value = lemma.Text
+		}
+	}
+
 	err = file.Save(tr.copyPathXLSX)
 	if err != nil {
 		appErr := apperrors.SaveWordsAsXLSXErr.AppendMessage(err)
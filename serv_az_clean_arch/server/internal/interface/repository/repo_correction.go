@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type correctionRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewCorrectionRepository(db *gorm.DB, log *logrus.Logger) repository.CorrectionRepository {
+	return &correctionRepository{db: db, log: log}
+}
+
+func (rt *correctionRepository) CreateCorrection(ctx context.Context, correction *models.Correction) error {
+	result := rt.db.WithContext(ctx).Create(correction)
+	if result.Error != nil {
+		appErr := apperrors.CreateCorrectionErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.CreateCorrectionErr.AppendMessage("no rows affected")
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *correctionRepository) GetCorrectionsByUserID(ctx context.Context, userID string) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+	err := rt.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&corrections).Error
+	if err != nil {
+		appErr := apperrors.GetCorrectionsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return corrections, nil
+}
+
+func (rt *correctionRepository) GetCorrectionsByStatus(ctx context.Context, status string) ([]*models.Correction, error) {
+	var corrections []*models.Correction
+	err := rt.db.WithContext(ctx).Where("status = ?", status).Order("id").Find(&corrections).Error
+	if err != nil {
+		appErr := apperrors.GetCorrectionsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return corrections, nil
+}
+
+func (rt *correctionRepository) GetCorrectionByID(ctx context.Context, id int) (*models.Correction, error) {
+	var corrections []*models.Correction
+	err := rt.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&corrections).Error
+	if err != nil {
+		appErr := apperrors.GetCorrectionByIDErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(corrections) == 0 {
+		appErr := apperrors.GetCorrectionByIDErr.AppendMessage("there is no correction with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return corrections[0], nil
+}
+
+// UpdateCorrection saves the review of a pending correction. A correction
+// reviewed meanwhile by another admin is left as it is.
+func (rt *correctionRepository) UpdateCorrection(ctx context.Context, correction *models.Correction) error {
+	result := rt.db.WithContext(ctx).Model(&models.Correction{}).
+		Where("id = ? AND status = ?", correction.ID, models.CorrectionPending).
+		Updates(map[string]interface{}{
+			"status":      correction.Status,
+			"new_value":   correction.NewValue,
+			"note":        correction.Note,
+			"reviewer_id": correction.ReviewerID,
+		})
+	if result.Error != nil {
+		appErr := apperrors.UpdateCorrectionErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.UpdateCorrectionErr.AppendMessage("no rows affected")
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type deckRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewDeckRepository(db *gorm.DB, log *logrus.Logger) repository.DeckRepository {
+	return &deckRepository{db: db, log: log}
+}
+
+// CreateDeck saves the deck with its words and puts learnIDs into the learn
+// queue of the deck's user, all or nothing.
+func (rt *deckRepository) CreateDeck(ctx context.Context, deck *models.Deck, wordIDs, learnIDs []int) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Words").Create(deck).Error; err != nil {
+			return err
+		}
+
+		for _, id := range wordIDs {
+			if err := tx.Exec("INSERT INTO deck_words (deck_id, word_id) VALUES (?, ?)", deck.ID, id).Error; err != nil {
+				return err
+			}
+		}
+
+		return addWordsToLearn(tx, deck.UserID, learnIDs)
+	})
+	if err != nil {
+		appErr := apperrors.CreateDeckErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *deckRepository) GetDecksByUserID(ctx context.Context, userID string) ([]*models.Deck, error) {
+	var decks []*models.Deck
+	err := rt.db.WithContext(ctx).Preload("Words").Preload("CustomWords").Where("user_id = ?", userID).Order("id DESC").Find(&decks).Error
+	if err != nil {
+		appErr := apperrors.GetDecksErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return decks, nil
+}
+
+func (rt *deckRepository) GetDeck(ctx context.Context, id int, userID string) (*models.Deck, error) {
+	var decks []*models.Deck
+	err := rt.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Limit(1).Find(&decks).Error
+	if err != nil {
+		appErr := apperrors.GetDeckErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(decks) == 0 {
+		appErr := apperrors.GetDeckErr.AppendMessage("there is no deck with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return decks[0], nil
+}
+
+// LoadDeckWords fills in the library and the custom words of the deck.
+func (rt *deckRepository) LoadDeckWords(ctx context.Context, deck *models.Deck) error {
+	err := rt.db.WithContext(ctx).Model(deck).Association("Words").Find(&deck.Words)
+	if err == nil {
+		err = rt.db.WithContext(ctx).Where("deck_id = ?", deck.ID).Order("id").Find(&deck.CustomWords).Error
+	}
+
+	if err != nil {
+		appErr := apperrors.LoadDeckWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// DeleteDeck removes the deck with its custom words for good, the library
+// words stay in the user's queues.
+func (rt *deckRepository) DeleteDeck(ctx context.Context, deck *models.Deck) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM deck_words WHERE deck_id = ?", deck.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("deck_id = ?", deck.ID).Delete(&models.CustomWord{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(deck).Error
+	})
+	if err != nil {
+		appErr := apperrors.DeleteDeckErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// GetDeckWords returns the deck words the user still has to pass: the ones
+// in the learn queue when learn is set, otherwise the not yet learned ones.
+func (rt *deckRepository) GetDeckWords(ctx context.Context, deck *models.Deck, learn bool, limit int) ([]*models.Word, error) {
+	queue := "user_words"
+	if learn {
+		queue = "user_learn"
+	}
+
+	words := []*models.Word{}
+	err := rt.db.WithContext(ctx).
+		Select("words.*", "libraries.image").
+		Joins("JOIN deck_words ON deck_words.word_id = words.id").
+		Joins("LEFT JOIN libraries ON libraries.id = words.id").
+		Joins("JOIN "+queue+" ON "+queue+".word_id = deck_words.word_id").
+		Where("deck_words.deck_id = ? AND "+queue+".user_id = ?", deck.ID, deck.UserID).
+		Order(wordsByLevelOrder).
+		Limit(limit).
+		Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetDeckWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return words, nil
+}
+
+func (rt *deckRepository) AddCustomWord(ctx context.Context, word *models.CustomWord) error {
+	if err := rt.db.WithContext(ctx).Create(word).Error; err != nil {
+		appErr := apperrors.AddCustomWordErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *deckRepository) DeleteCustomWord(ctx context.Context, id int, userID string) error {
+	result := rt.db.WithContext(ctx).Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&models.CustomWord{})
+	if result.Error != nil {
+		appErr := apperrors.DeleteCustomWordErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.DeleteCustomWordErr.AppendMessage("there is no custom word with id", id)
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// GetCustomWords returns the custom words of the deck in the learn queue when
+// learn is set, otherwise the not yet learned ones.
+func (rt *deckRepository) GetCustomWords(ctx context.Context, deck *models.Deck, learn bool, limit int) ([]*models.CustomWord, error) {
+	query := rt.db.WithContext(ctx).
+		Table("custom_words").
+		Select("custom_words.*", "libraries.image").
+		Joins("LEFT JOIN libraries ON libraries.id = custom_words.library_id").
+		Where("custom_words.deck_id = ? AND custom_words.user_id = ? AND custom_words.deleted_at IS NULL", deck.ID, deck.UserID)
+	if learn {
+		query = query.Where("custom_words.learn = ?", true)
+	} else {
+		query = query.Where("custom_words.learned = ?", false)
+	}
+
+	words := []*models.CustomWord{}
+	if err := query.Order("custom_words.id").Limit(limit).Find(&words).Error; err != nil {
+		appErr := apperrors.GetCustomWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return words, nil
+}
+
+func (rt *deckRepository) UpdateCustomWordProgress(ctx context.Context, id int, userID string, learn, learned bool) error {
+	err := rt.db.WithContext(ctx).Model(&models.CustomWord{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{"learn": learn, "learned": learned}).Error
+	if err != nil {
+		appErr := apperrors.UpdateCustomWordErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type disputeRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewDisputeRepository(db *gorm.DB, log *logrus.Logger) repository.DisputeRepository {
+	return &disputeRepository{db: db, log: log}
+}
+
+func (rt *disputeRepository) CreateDispute(ctx context.Context, dispute *models.Dispute) error {
+	result := rt.db.WithContext(ctx).Create(dispute)
+	if result.Error != nil {
+		appErr := apperrors.CreateDisputeErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.CreateDisputeErr.AppendMessage("no rows affected")
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *disputeRepository) GetDisputesByUserID(ctx context.Context, userID string) ([]*models.Dispute, error) {
+	var disputes []*models.Dispute
+	err := rt.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&disputes).Error
+	if err != nil {
+		appErr := apperrors.GetDisputesErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return disputes, nil
+}
+
+func (rt *disputeRepository) GetDisputesByStatus(ctx context.Context, status string) ([]*models.Dispute, error) {
+	var disputes []*models.Dispute
+	err := rt.db.WithContext(ctx).Where("status = ?", status).Order("id").Find(&disputes).Error
+	if err != nil {
+		appErr := apperrors.GetDisputesErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return disputes, nil
+}
+
+func (rt *disputeRepository) GetDisputeByID(ctx context.Context, id int) (*models.Dispute, error) {
+	var disputes []*models.Dispute
+	err := rt.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&disputes).Error
+	if err != nil {
+		appErr := apperrors.GetDisputeByIDErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(disputes) == 0 {
+		appErr := apperrors.GetDisputeByIDErr.AppendMessage("there is no dispute with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return disputes[0], nil
+}
+
+func (rt *disputeRepository) UpdateDisputeStatus(ctx context.Context, id int, status, note string) error {
+	result := rt.db.WithContext(ctx).Model(&models.Dispute{}).Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": status,
+			"note":   note,
+		})
+	if result.Error != nil {
+		appErr := apperrors.UpdateDisputeErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.UpdateDisputeErr.AppendMessage("no rows affected")
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
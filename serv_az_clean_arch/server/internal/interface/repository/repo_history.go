@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type historyRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewHistoryRepository(db *gorm.DB, log *logrus.Logger) repository.HistoryRepository {
+	return &historyRepository{db: db, log: log}
+}
+
+func (rt *historyRepository) AddLookup(ctx context.Context, lookup *models.LookupHistory) error {
+	if err := rt.db.WithContext(ctx).Create(lookup).Error; err != nil {
+		appErr := apperrors.AddLookupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *historyRepository) GetUserHistory(ctx context.Context, userID string, limit int) ([]*models.LookupHistory, error) {
+	var history []*models.LookupHistory
+	err := rt.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Limit(limit).Find(&history).Error
+	if err != nil {
+		appErr := apperrors.GetUserHistoryErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return history, nil
+}
+
+// DeleteUserHistory removes the user's lookups for good.
+func (rt *historyRepository) DeleteUserHistory(ctx context.Context, userID string) error {
+	err := rt.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Delete(&models.LookupHistory{}).Error
+	if err != nil {
+		appErr := apperrors.DeleteUserHistoryErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
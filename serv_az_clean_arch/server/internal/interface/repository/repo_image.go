@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"server/internal/apperrors"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// thumbsDir is the subdirectory of the images directory holding thumbnails
+// under the same names as the originals.
+const thumbsDir = "thumbs"
+
+// thumbnailSize is the longest side of a thumbnail in pixels.
+const thumbnailSize = 240
+
+type imageRepository struct {
+	dir string
+	log *logrus.Logger
+}
+
+func NewImageRepository(dir string, log *logrus.Logger) repository.ImageRepository {
+	return &imageRepository{dir: dir, log: log}
+}
+
+// SaveImage stores the image under the hash of its content, so the same
+// picture uploaded for several words is kept once, and writes its thumbnail.
+func (rt *imageRepository) SaveImage(data []byte, format string) (string, error) {
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:]) + "." + format
+
+	if err := os.MkdirAll(filepath.Join(rt.dir, thumbsDir), 0o755); err != nil {
+		appErr := apperrors.SaveImageErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return "", appErr
+	}
+
+	path := filepath.Join(rt.dir, name)
+	thumbPath := filepath.Join(rt.dir, thumbsDir, name)
+	if fileExists(path) && fileExists(thumbPath) {
+		return name, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		appErr := apperrors.SaveImageErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return "", appErr
+	}
+
+	thumb, err := encodeImage(thumbnail(img, thumbnailSize), format)
+	if err != nil {
+		appErr := apperrors.SaveImageErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return "", appErr
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		appErr := apperrors.SaveImageErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return "", appErr
+	}
+
+	if err := writeFileAtomic(thumbPath, thumb); err != nil {
+		appErr := apperrors.SaveImageErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return "", appErr
+	}
+
+	return name, nil
+}
+
+// writeFileAtomic writes the file under a temporary name and renames it, so
+// a failed write never leaves a partial picture under the name pages use.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func encodeImage(img image.Image, format string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(buf, img, &jpeg.Options{Quality: 85})
+	case "gif":
+		err = gif.Encode(buf, img, nil)
+	default:
+		err = png.Encode(buf, img)
+	}
+
+	return buf.Bytes(), err
+}
+
+// thumbnail scales the image down so its longest side is at most size,
+// averaging the source pixels covered by each thumbnail pixel.
+func thumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	thumbWidth, thumbHeight := size, height*size/width
+	if height > width {
+		thumbWidth, thumbHeight = width*size/height, size
+	}
+
+	if thumbWidth == 0 {
+		thumbWidth = 1
+	}
+
+	if thumbHeight == 0 {
+		thumbHeight = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/thumbHeight, bounds.Min.Y+(y+1)*height/thumbHeight
+		for x := 0; x < thumbWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/thumbWidth, bounds.Min.X+(x+1)*width/thumbWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,628 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/morphology"
+	"server/internal/usercase/repository"
+	"server/internal/usercase/search"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// LemmasLocalMap maps a library word ID to its lemmas by language, linked
+// translations included. It is swapped as a whole on every rebuild.
+var LemmasLocalMap atomic.Pointer[map[int]map[string][]string]
+
+// lemmasIndex is swapped as a whole on every rebuild, lookups never see a
+// half built index.
+var lemmasIndex atomic.Pointer[lemmasSearch]
+
+type lemmasSearch struct {
+	index  *search.Index
+	lemmas map[int]*models.Lemma
+	// stems maps a language and a stem to the lemmas having it
+	stems map[string]map[string][]*models.Lemma
+	// roots maps a library root to the English lemmas of its words
+	roots map[string][]*models.Lemma
+}
+
+// libraryLanguages are the languages stored as library columns, their lemmas
+// are kept in sync with the library.
+var libraryLanguages = []string{models.LangEnglish, models.LangRussian}
+
+type lemmaRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewLemmaRepository(db *gorm.DB, log *logrus.Logger) repository.LemmaRepository {
+	return &lemmaRepository{db: db, log: log}
+}
+
+// SyncLibraryLemmas makes the English and Russian lemmas match the library
+// columns, links them to each other and drops lemmas of deleted words.
+func (rt *lemmaRepository) SyncLibraryLemmas(ctx context.Context) (int, error) {
+	var library []*models.Library
+	if err := rt.db.WithContext(ctx).Select("id", "english", "russian").Find(&library).Error; err != nil {
+		appErr := apperrors.SyncLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	var lemmas []*models.Lemma
+	if err := rt.db.WithContext(ctx).Where("library_id > 0").Find(&lemmas).Error; err != nil {
+		appErr := apperrors.SyncLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	words := make(map[int]*models.Library, len(library))
+	for _, word := range library {
+		words[word.ID] = word
+	}
+
+	existing := make(map[int]map[string]*models.Lemma, len(library))
+	orphans := []int{}
+	for _, lemma := range lemmas {
+		if words[lemma.LibraryID] == nil {
+			orphans = append(orphans, lemma.ID)
+			continue
+		}
+
+		if existing[lemma.LibraryID] == nil {
+			existing[lemma.LibraryID] = make(map[string]*models.Lemma)
+		}
+
+		if isLibraryLanguage(lemma.Language) {
+			existing[lemma.LibraryID][lemma.Language] = lemma
+		}
+	}
+
+	created := []*models.Lemma{}
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(orphans) > 0 {
+			if err := tx.Unscoped().Where("from_lemma_id IN ? OR to_lemma_id IN ?", orphans, orphans).Delete(&models.TranslationLink{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Where("id IN ?", orphans).Delete(&models.Lemma{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, word := range library {
+			texts := map[string]string{models.LangEnglish: word.English, models.LangRussian: word.Russian}
+			for _, language := range libraryLanguages {
+				lemma := existing[word.ID][language]
+				if lemma == nil {
+					created = append(created, &models.Lemma{Language: language, Text: texts[language], LibraryID: word.ID})
+					continue
+				}
+
+				if lemma.Text != texts[language] {
+					if err := tx.Model(lemma).Update("text", texts[language]).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if len(created) > 0 {
+			if err := tx.CreateInBatches(created, 300).Error; err != nil {
+				return err
+			}
+		}
+
+		return rt.linkLibraryLemmas(tx)
+	})
+	if err != nil {
+		appErr := apperrors.SyncLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	if err := rt.UpdateLemmasMap(); err != nil {
+		return 0, err
+	}
+
+	return len(created), nil
+}
+
+// saveWordLemmas writes the English and Russian of a library word to its
+// lemmas and links them, in the transaction that writes the word.
+func saveWordLemmas(tx *gorm.DB, word *models.Library) error {
+	var lemmas []*models.Lemma
+	if err := tx.Where("library_id = ? AND language IN ?", word.ID, libraryLanguages).Find(&lemmas).Error; err != nil {
+		return err
+	}
+
+	existing := make(map[string]*models.Lemma, len(lemmas))
+	for _, lemma := range lemmas {
+		existing[lemma.Language] = lemma
+	}
+
+	texts := map[string]string{models.LangEnglish: word.English, models.LangRussian: word.Russian}
+	for _, language := range libraryLanguages {
+		lemma := existing[language]
+		if lemma == nil {
+			if err := tx.Create(&models.Lemma{Language: language, Text: texts[language], LibraryID: word.ID}).Error; err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if lemma.Text != texts[language] {
+			if err := tx.Model(lemma).Update("text", texts[language]).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return linkWordLemmas(tx, word.ID)
+}
+
+// linkLibraryLemmas links every non English lemma of a library word to its
+// English lemma, so English works as the hub between any two languages.
+func (rt *lemmaRepository) linkLibraryLemmas(tx *gorm.DB) error {
+	var lemmas []*models.Lemma
+	if err := tx.Where("library_id > 0").Find(&lemmas).Error; err != nil {
+		return err
+	}
+
+	var links []*models.TranslationLink
+	if err := tx.Find(&links).Error; err != nil {
+		return err
+	}
+
+	return createMissingLinks(tx, lemmas, links)
+}
+
+// linkWordLemmas is linkLibraryLemmas for the lemmas of one library word.
+func linkWordLemmas(tx *gorm.DB, libraryID int) error {
+	var lemmas []*models.Lemma
+	if err := tx.Where("library_id = ?", libraryID).Find(&lemmas).Error; err != nil {
+		return err
+	}
+
+	if len(lemmas) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(lemmas))
+	for _, lemma := range lemmas {
+		ids = append(ids, lemma.ID)
+	}
+
+	var links []*models.TranslationLink
+	if err := tx.Where("from_lemma_id IN ? OR to_lemma_id IN ?", ids, ids).Find(&links).Error; err != nil {
+		return err
+	}
+
+	return createMissingLinks(tx, lemmas, links)
+}
+
+func createMissingLinks(tx *gorm.DB, lemmas []*models.Lemma, links []*models.TranslationLink) error {
+	linked := make(map[[2]int]bool, len(links))
+	for _, link := range links {
+		linked[[2]int{link.FromLemmaID, link.ToLemmaID}] = true
+		linked[[2]int{link.ToLemmaID, link.FromLemmaID}] = true
+	}
+
+	english := make(map[int]int)
+	for _, lemma := range lemmas {
+		if lemma.Language == models.LangEnglish {
+			english[lemma.LibraryID] = lemma.ID
+		}
+	}
+
+	missing := []*models.TranslationLink{}
+	for _, lemma := range lemmas {
+		hub, ok := english[lemma.LibraryID]
+		if !ok || lemma.Language == models.LangEnglish || linked[[2]int{hub, lemma.ID}] {
+			continue
+		}
+
+		missing = append(missing, &models.TranslationLink{FromLemmaID: hub, ToLemmaID: lemma.ID})
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return tx.CreateInBatches(missing, 300).Error
+}
+
+// deleteWordLemmas removes the lemmas of a deleted library word with their
+// links.
+func deleteWordLemmas(tx *gorm.DB, libraryID int) error {
+	err := tx.Exec(`DELETE FROM translation_links
+		WHERE from_lemma_id IN (SELECT id FROM lemmas WHERE library_id = ?) OR to_lemma_id IN (SELECT id FROM lemmas WHERE library_id = ?)`,
+		libraryID, libraryID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("library_id = ?", libraryID).Delete(&models.Lemma{}).Error
+}
+
+func (rt *lemmaRepository) UpdateLemmasMap() error {
+	var lemmas []*models.Lemma
+	if err := rt.db.Find(&lemmas).Error; err != nil {
+		appErr := apperrors.UpdateLemmasMapErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	var links []*models.TranslationLink
+	if err := rt.db.Find(&links).Error; err != nil {
+		appErr := apperrors.UpdateLemmasMapErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	byID := make(map[int]*models.Lemma, len(lemmas))
+	lemmasMap := make(map[int]map[string][]string)
+	add := func(libraryID int, lemma *models.Lemma) {
+		if libraryID == 0 {
+			return
+		}
+
+		if lemmasMap[libraryID] == nil {
+			lemmasMap[libraryID] = make(map[string][]string)
+		}
+
+		for _, text := range lemmasMap[libraryID][lemma.Language] {
+			if text == lemma.Text {
+				return
+			}
+		}
+
+		lemmasMap[libraryID][lemma.Language] = append(lemmasMap[libraryID][lemma.Language], lemma.Text)
+	}
+
+	for _, lemma := range lemmas {
+		byID[lemma.ID] = lemma
+		add(lemma.LibraryID, lemma)
+	}
+
+	for _, link := range links {
+		from, to := byID[link.FromLemmaID], byID[link.ToLemmaID]
+		if from == nil || to == nil {
+			continue
+		}
+
+		add(from.LibraryID, to)
+		add(to.LibraryID, from)
+	}
+
+	var library []*models.Library
+	if err := rt.db.Select("id", "root").Where("root <> ''").Find(&library).Error; err != nil {
+		appErr := apperrors.UpdateLemmasMapErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	roots := make(map[int]string, len(library))
+	for _, word := range library {
+		roots[word.ID] = search.Normalize(word.Root)
+	}
+
+	current := &lemmasSearch{
+		lemmas: byID,
+		stems:  make(map[string]map[string][]*models.Lemma),
+		roots:  make(map[string][]*models.Lemma),
+	}
+	entries := make([]search.Entry, 0, len(lemmas))
+	for _, lemma := range lemmas {
+		entries = append(entries, search.Entry{ID: lemma.ID, Language: lemma.Language, Text: lemma.Text})
+		if stem := morphology.Stem(lemma.Language, lemma.Text); stem != "" {
+			if current.stems[lemma.Language] == nil {
+				current.stems[lemma.Language] = make(map[string][]*models.Lemma)
+			}
+
+			current.stems[lemma.Language][stem] = append(current.stems[lemma.Language][stem], lemma)
+		}
+
+		if root := roots[lemma.LibraryID]; root != "" && lemma.Language == models.LangEnglish {
+			current.roots[root] = append(current.roots[root], lemma)
+		}
+	}
+
+	current.index = search.NewIndex(entries)
+	LemmasLocalMap.Store(&lemmasMap)
+	lemmasIndex.Store(current)
+	return nil
+}
+
+func (rt *lemmaRepository) ConceptLemmas(libraryID int) map[string][]string {
+	lemmasMap := LemmasLocalMap.Load()
+	if lemmasMap == nil {
+		return nil
+	}
+
+	return (*lemmasMap)[libraryID]
+}
+
+// SearchLemmas looks the text up in the lemmas index. Without like only
+// exact matches are returned, with it prefix and substring matches follow.
+func (rt *lemmaRepository) SearchLemmas(language, text string, like bool, limit int) []*models.Lemma {
+	lemmas := []*models.Lemma{}
+	current := lemmasIndex.Load()
+	if current == nil {
+		return lemmas
+	}
+
+	for _, match := range current.index.Search(language, text, limit) {
+		if !like && match.Rank != search.Exact {
+			break
+		}
+
+		lemmas = append(lemmas, current.lemmas[match.ID])
+	}
+
+	return lemmas
+}
+
+// FuzzyLemmas returns the lemmas of language closest to the text within the
+// edit distance budget.
+func (rt *lemmaRepository) FuzzyLemmas(language, text string, budget, limit int) []*models.Lemma {
+	lemmas := []*models.Lemma{}
+	current := lemmasIndex.Load()
+	if current == nil {
+		return lemmas
+	}
+
+	for _, match := range current.index.Fuzzy(language, text, budget, limit) {
+		lemmas = append(lemmas, current.lemmas[match.ID])
+	}
+
+	return lemmas
+}
+
+// StemLemmas returns the lemmas of language sharing the stem of the text.
+func (rt *lemmaRepository) StemLemmas(language, text string) []*models.Lemma {
+	current := lemmasIndex.Load()
+	stem := morphology.Stem(language, text)
+	if current == nil || stem == "" {
+		return []*models.Lemma{}
+	}
+
+	return append([]*models.Lemma{}, current.stems[language][stem]...)
+}
+
+// RootLemmas returns the English lemmas of the library words with the root.
+func (rt *lemmaRepository) RootLemmas(root string) []*models.Lemma {
+	current := lemmasIndex.Load()
+	if current == nil {
+		return []*models.Lemma{}
+	}
+
+	return append([]*models.Lemma{}, current.roots[search.Normalize(root)]...)
+}
+
+// GetTranslations returns the lemmas of language that share a library word
+// with one of from or are linked to it.
+func (rt *lemmaRepository) GetTranslations(ctx context.Context, from []*models.Lemma, language string) ([]*models.Translation, error) {
+	translations := []*models.Translation{}
+	if len(from) == 0 {
+		return translations, nil
+	}
+
+	ids := make([]int, 0, len(from))
+	libraryIDs := []int{0}
+	for _, lemma := range from {
+		ids = append(ids, lemma.ID)
+		if lemma.LibraryID > 0 {
+			libraryIDs = append(libraryIDs, lemma.LibraryID)
+		}
+	}
+
+	var links []*models.TranslationLink
+	err := rt.db.WithContext(ctx).Where("from_lemma_id IN ? OR to_lemma_id IN ?", ids, ids).Find(&links).Error
+	if err != nil {
+		appErr := apperrors.GetTranslationsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	linkedIDs := []int{0}
+	for _, link := range links {
+		linkedIDs = append(linkedIDs, link.FromLemmaID, link.ToLemmaID)
+	}
+
+	var targets []*models.Lemma
+	err = rt.db.WithContext(ctx).
+		Where("language = ? AND (library_id IN ? OR id IN ?)", language, libraryIDs[1:], linkedIDs).
+		Order("text").
+		Find(&targets).Error
+	if err != nil {
+		appErr := apperrors.GetTranslationsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	var library []*models.Library
+	if err := rt.db.WithContext(ctx).Where("id IN ?", libraryIDs).Find(&library).Error; err != nil {
+		appErr := apperrors.GetTranslationsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	words := make(map[int]*models.Library, len(library))
+	for _, word := range library {
+		words[word.ID] = word
+	}
+
+	linked := make(map[[2]int]bool, len(links))
+	for _, link := range links {
+		linked[[2]int{link.FromLemmaID, link.ToLemmaID}] = true
+		linked[[2]int{link.ToLemmaID, link.FromLemmaID}] = true
+	}
+
+	for _, source := range from {
+		for _, target := range targets {
+			sameWord := source.LibraryID > 0 && source.LibraryID == target.LibraryID
+			if !sameWord && !linked[[2]int{source.ID, target.ID}] {
+				continue
+			}
+
+			translations = append(translations, &models.Translation{From: source, To: target, Library: words[source.LibraryID]})
+		}
+	}
+
+	return translations, nil
+}
+
+func (rt *lemmaRepository) GetLemmasByLibraryID(ctx context.Context, libraryID int) ([]*models.Lemma, error) {
+	var lemmas []*models.Lemma
+	err := rt.db.WithContext(ctx).Where("library_id = ?", libraryID).Order("language").Order("text").Find(&lemmas).Error
+	if err != nil {
+		appErr := apperrors.GetLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return lemmas, nil
+}
+
+// GetExtraLemmas returns the lemmas that aren't library columns.
+func (rt *lemmaRepository) GetExtraLemmas(ctx context.Context) ([]*models.Lemma, error) {
+	var lemmas []*models.Lemma
+	err := rt.db.WithContext(ctx).Where("language NOT IN ?", libraryLanguages).Order("library_id").Order("language").Find(&lemmas).Error
+	if err != nil {
+		appErr := apperrors.GetLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return lemmas, nil
+}
+
+func (rt *lemmaRepository) CountLemmas(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Language string
+		Count    int64
+	}
+
+	err := rt.db.WithContext(ctx).Model(&models.Lemma{}).Select("language, COUNT(*) AS count").Group("language").Scan(&rows).Error
+	if err != nil {
+		appErr := apperrors.GetLemmasErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Language] = row.Count
+	}
+
+	return counts, nil
+}
+
+// AddLemma stores the lemma unless the word already has it and links it to
+// the English lemma of the word.
+func (rt *lemmaRepository) AddLemma(ctx context.Context, lemma *models.Lemma) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []*models.Lemma
+		err := tx.Where("library_id = ? AND language = ? AND text = ?", lemma.LibraryID, lemma.Language, lemma.Text).Limit(1).Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			*lemma = *existing[0]
+			return nil
+		}
+
+		if err := tx.Create(lemma).Error; err != nil {
+			return err
+		}
+
+		return linkWordLemmas(tx, lemma.LibraryID)
+	})
+	if err != nil {
+		appErr := apperrors.AddLemmaErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return rt.UpdateLemmasMap()
+}
+
+// AddLinkedLemma adds a lemma that belongs to no library word and links it
+// to the English lemma of the word, for translations in a library language
+// whose own lemma mirrors the library column.
+func (rt *lemmaRepository) AddLinkedLemma(ctx context.Context, libraryID int, lemma *models.Lemma) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var hub models.Lemma
+		if err := tx.Where("library_id = ? AND language = ?", libraryID, models.LangEnglish).First(&hub).Error; err != nil {
+			return err
+		}
+
+		lemma.LibraryID = 0
+		if err := tx.Where("library_id = 0 AND language = ? AND text = ?", lemma.Language, lemma.Text).FirstOrCreate(lemma).Error; err != nil {
+			return err
+		}
+
+		link := &models.TranslationLink{}
+		return tx.Where("(from_lemma_id = ? AND to_lemma_id = ?) OR (from_lemma_id = ? AND to_lemma_id = ?)", hub.ID, lemma.ID, lemma.ID, hub.ID).
+			Attrs(models.TranslationLink{FromLemmaID: hub.ID, ToLemmaID: lemma.ID}).
+			FirstOrCreate(link).Error
+	})
+	if err != nil {
+		appErr := apperrors.AddLemmaErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return rt.UpdateLemmasMap()
+}
+
+func (rt *lemmaRepository) DeleteLemma(ctx context.Context, id int) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("from_lemma_id = ? OR to_lemma_id = ?", id, id).Delete(&models.TranslationLink{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id = ? AND language NOT IN ?", id, libraryLanguages).Delete(&models.Lemma{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return apperrors.DeleteLemmaErr.AppendMessage("there is no lemma with id", id, "or it is a library column")
+		}
+
+		return nil
+	})
+	if err != nil {
+		appErr := apperrors.DeleteLemmaErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return rt.UpdateLemmasMap()
+}
+
+// moveWordLemmas moves the lemmas that aren't library columns to another
+// word. The caller deletes the library column lemmas of the old word.
+func moveWordLemmas(tx *gorm.DB, fromID, toID int) error {
+	return tx.Model(&models.Lemma{}).
+		Where("library_id = ? AND language NOT IN ?", fromID, libraryLanguages).
+		Update("library_id", toID).Error
+}
+
+func isLibraryLanguage(language string) bool {
+	for _, known := range libraryLanguages {
+		if language == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
 	"server/internal/apperrors"
 	"server/internal/domain/models"
+	"server/internal/domain/requests"
 	"server/internal/usercase/repository"
+	"sync/atomic"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -19,121 +24,69 @@ func NewLibraryRepository(db *gorm.DB, log *logrus.Logger) repository.LibraryRep
 	return &libraryRepository{db: db, log: log}
 }
 
-var WordsLibraryLocalMap *map[string][]string
+// TranslationGroupsLocalMap maps a library word ID to every answer accepted
+// through its translation group. The map is swapped as a whole on every
+// rebuild, readers never see a half built one.
+var TranslationGroupsLocalMap atomic.Pointer[map[int][]string]
 
 func (rt *libraryRepository) InitWordsMap() error {
-	lib, err := rt.GetAllWords()
-	if err != nil {
+	if err := rt.buildWordsMaps(); err != nil {
 		appErr := apperrors.InitWordsMapErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
-	wordsMap := make(map[string][]string)
-
-	for _, word := range lib {
-		wordsMap[word.Russian] = append(wordsMap[word.Russian], word.English)
-	}
-
-	WordsLibraryLocalMap = &wordsMap
 	return nil
 }
 
 func (rt *libraryRepository) UpdateWordsMap() error {
-	lib, err := rt.GetAllWords()
-	if err != nil {
+	if err := rt.buildWordsMaps(); err != nil {
 		appErr := apperrors.UpdateWordsMapErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
-	wordsMap := make(map[string][]string)
-
-	for _, word := range lib {
-		wordsMap[word.Russian] = append(wordsMap[word.Russian], word.English)
-	}
-
-	WordsLibraryLocalMap = &wordsMap
 	return nil
 }
 
-func (rt *libraryRepository) GetAllWords() ([]*models.Library, error) {
-	var words []*models.Library
-	err := rt.db.Order("theme").Find(&words).Error
+func (rt *libraryRepository) buildWordsMaps() error {
+	lib, err := rt.GetAllWords()
 	if err != nil {
-		appErr := apperrors.GetAllWordsLibErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
+		return err
 	}
 
-	return words, nil
-}
-
-func (rt *libraryRepository) GetTranslationRus(word string) ([]*models.Library, error) {
-	var words []*models.Library
-	err := rt.db.Where("russian = ?", word).Find(&words).Error
-	if err != nil {
-		appErr := apperrors.GetTranslationRusErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
+	var variants []*models.TranslationVariant
+	if err := rt.db.Find(&variants).Error; err != nil {
+		return err
 	}
 
-	return words, nil
-}
-
-func (rt *libraryRepository) GetTranslationRusLike(word string) ([]*models.Library, error) {
-	var words []*models.Library
-	err := rt.db.Where("russian LIKE ?", "%"+word+"%").Find(&words).Error
-	if err != nil {
-		appErr := apperrors.GetTranslationRusLikeErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
+	groupAnswers := make(map[int][]string)
+	for _, word := range lib {
+		if word.TranslationGroupID > 0 {
+			groupAnswers[word.TranslationGroupID] = append(groupAnswers[word.TranslationGroupID], word.English)
+		}
 	}
 
-	return words, nil
-}
-
-func (rt *libraryRepository) GetTranslationRusLikeWord(word string) (*models.Library, error) {
-	var words *models.Library
-	err := rt.db.Where("russian LIKE ?", "%"+word+"%").Limit(1).Find(&words).Error
-	if err != nil {
-		appErr := apperrors.GetTranslationRusLikeErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
+	for _, variant := range variants {
+		groupAnswers[variant.TranslationGroupID] = append(groupAnswers[variant.TranslationGroupID], variant.English)
 	}
 
-	return words, nil
-}
-
-func (rt *libraryRepository) GetTranslationEngl(word string) ([]*models.Library, error) {
-	var words []*models.Library
-	err := rt.db.Where("english = ?", word).Find(&words).Error
-	if err != nil {
-		appErr := apperrors.GetTranslationEnglErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
+	groupsMap := make(map[int][]string)
+	for _, word := range lib {
+		if word.TranslationGroupID > 0 {
+			groupsMap[word.ID] = groupAnswers[word.TranslationGroupID]
+		}
 	}
 
-	return words, nil
+	TranslationGroupsLocalMap.Store(&groupsMap)
+	return nil
 }
 
-func (rt *libraryRepository) GetTranslationEnglLike(word string) ([]*models.Library, error) {
+func (rt *libraryRepository) GetAllWords() ([]*models.Library, error) {
 	var words []*models.Library
-	err := rt.db.Where("english LIKE ?", "%"+word+"%").Find(&words).Error
-	if err != nil {
-		appErr := apperrors.GetTranslationEnglLikeErr.AppendMessage(err)
-		rt.log.Error(appErr)
-		return nil, appErr
-	}
-
-	return words, nil
-}
-
-func (rt *libraryRepository) GetTranslationEnglLikeWord(word string) (*models.Library, error) {
-	var words *models.Library
-	err := rt.db.Where("english LIKE ?", "%"+word+"%").Limit(1).Find(&words).Error
+	err := rt.db.Order("theme").Find(&words).Error
 	if err != nil {
-		appErr := apperrors.GetTranslationEnglLikeErr.AppendMessage(err)
+		appErr := apperrors.GetAllWordsLibErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return nil, appErr
 	}
@@ -187,29 +140,282 @@ func (rt *libraryRepository) InsertWordLibrary(ctx context.Context, word *models
 		return appErr
 	}
 
-	tx := rt.db.WithContext(ctx)
-	if tx.Error != nil {
-		appErr := apperrors.InsertWordLibraryErr.AppendMessage(tx.Error)
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Create(word)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("no rows affected")
+		}
+
+		return saveWordLemmas(tx, word)
+	})
+	if err != nil {
+		appErr := apperrors.InsertWordLibraryErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
-	result := tx.Create(word)
-	if result.Error != nil {
-		appErr := apperrors.InsertWordLibraryErr.AppendMessage(result.Error)
+	return nil
+}
+
+// UpdateWord writes the word over its library row, saves the values it
+// replaces as a version credited to authorID and bumps the version of the
+// row. Writing the same values again records nothing.
+func (rt *libraryRepository) UpdateWord(ctx context.Context, word *models.Library, authorID string) error {
+	fields := libraryWordFields(word)
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []*models.Library
+		if err := tx.Where("id = ?", word.ID).Limit(1).Find(&current).Error; err != nil {
+			return err
+		}
+
+		if len(current) == 0 {
+			return &apperrors.UpdateWordRowAffectedErr
+		}
+
+		previous := libraryWordFields(current[0])
+		if reflect.DeepEqual(previous, fields) {
+			return nil
+		}
+
+		values, err := json.Marshal(previous)
+		if err != nil {
+			return err
+		}
+
+		version := &models.LibraryVersion{LibraryID: word.ID, Version: current[0].Version, AuthorID: authorID, Values: string(values)}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+
+		fields["version"] = current[0].Version + 1
+		fields["edited_by"] = authorID
+		if err := tx.Model(&models.Library{}).Where("id = ?", word.ID).Updates(fields).Error; err != nil {
+			return err
+		}
+
+		return saveWordLemmas(tx, word)
+	})
+	if err == &apperrors.UpdateWordRowAffectedErr {
+		rt.log.Info(err)
+		return err
+	}
+
+	if err != nil {
+		appErr := apperrors.UpdateWordErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
-	if result.RowsAffected == 0 {
-		appErr := apperrors.InsertWordLibraryErr.AppendMessage("no rows affected")
+	return nil
+}
+
+// libraryWordFields are the columns of a library word the library forms and
+// files write.
+func libraryWordFields(word *models.Library) map[string]interface{} {
+	return map[string]interface{}{
+		"english":              word.English,
+		"russian":              word.Russian,
+		"theme":                word.Theme,
+		"preposition":          word.Preposition,
+		"parts_of_speech":      word.PartsOfSpeech,
+		"root":                 word.Root,
+		"topic_id":             word.TopicID,
+		"level":                word.Level,
+		"frequency":            word.Frequency,
+		"transcription":        word.Transcription,
+		"russian_stressed":     word.RussianStressed,
+		"forms":                word.Forms,
+		"register":             word.Register,
+		"usage_note":           word.UsageNote,
+		"translation_group_id": word.TranslationGroupID,
+	}
+}
+
+func (rt *libraryRepository) GetAllTopics() ([]string, error) {
+	var themes []string
+	err := rt.db.Table("libraries").Select("DISTINCT(theme)").Pluck("DISTINCT(theme)", &themes).Error
+	if err != nil {
+		appErr := apperrors.GetAllTopicsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return themes, nil
+}
+
+func (rt *libraryRepository) CountWords() (int64, error) {
+	var count int64
+	err := rt.db.Model(&models.Library{}).Count(&count).Error
+	if err != nil {
+		appErr := apperrors.CountWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	return count, nil
+}
+
+func (rt *libraryRepository) IsLibrarySeeded(ctx context.Context) (bool, error) {
+	var count int64
+	err := rt.db.WithContext(ctx).Model(&models.LibrarySeed{}).Count(&count).Error
+	if err != nil {
+		appErr := apperrors.LibrarySeedErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return false, appErr
+	}
+
+	return count > 0, nil
+}
+
+func (rt *libraryRepository) MarkLibrarySeeded(ctx context.Context, seed *models.LibrarySeed) error {
+	if err := rt.db.WithContext(ctx).Create(seed).Error; err != nil {
+		appErr := apperrors.LibrarySeedErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
-	createdLib := &models.Library{}
-	if err := tx.First(createdLib, "id = ?", word.ID).Error; err != nil {
-		appErr := apperrors.InsertWordLibraryErr.AppendMessage(err)
+	return nil
+}
+
+func (rt *libraryRepository) GetWordsByFilter(ctx context.Context, filter *requests.LibraryFilterRequest) ([]*models.Library, int64, error) {
+	var total int64
+	err := rt.filterQuery(ctx, filter).Count(&total).Error
+	if err != nil {
+		appErr := apperrors.GetWordsByFilterErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, 0, appErr
+	}
+
+	var words []*models.Library
+	err = rt.filterQuery(ctx, filter).
+		Order("id").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetWordsByFilterErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, 0, appErr
+	}
+
+	return words, total, nil
+}
+
+func (rt *libraryRepository) filterQuery(ctx context.Context, filter *requests.LibraryFilterRequest) *gorm.DB {
+	query := rt.db.WithContext(ctx).Model(&models.Library{})
+	if filter.Theme != "" {
+		query = query.Where("theme = ?", filter.Theme)
+	}
+
+	if filter.PartsOfSpeech != "" {
+		query = query.Where("parts_of_speech = ?", filter.PartsOfSpeech)
+	}
+
+	if filter.Level != "" {
+		query = query.Where("level = ?", filter.Level)
+	}
+
+	if filter.Text != "" {
+		like := "%" + filter.Text + "%"
+		query = query.Where("english LIKE ? OR russian LIKE ? OR root LIKE ?", like, like, like)
+	}
+
+	return query
+}
+
+// GetWordsByIDs returns the words in the order of ids, missing ones skipped.
+func (rt *libraryRepository) GetWordsByIDs(ctx context.Context, ids []int) ([]*models.Library, error) {
+	words := []*models.Library{}
+	if len(ids) == 0 {
+		return words, nil
+	}
+
+	var found []*models.Library
+	if err := rt.db.WithContext(ctx).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		appErr := apperrors.GetWordsByIDsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	byID := make(map[int]*models.Library, len(found))
+	for _, word := range found {
+		byID[word.ID] = word
+	}
+
+	for _, id := range ids {
+		if word, ok := byID[id]; ok {
+			words = append(words, word)
+		}
+	}
+
+	return words, nil
+}
+
+func (rt *libraryRepository) GetWordByID(ctx context.Context, id int) (*models.Library, error) {
+	var words []*models.Library
+	err := rt.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetWordByIDErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(words) == 0 {
+		appErr := apperrors.GetWordByIDErr.AppendMessage("there is no word with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return words[0], nil
+}
+
+func (rt *libraryRepository) GetMaxID(ctx context.Context) (int, error) {
+	var maxID int
+	err := rt.db.WithContext(ctx).Unscoped().Model(&models.Library{}).Select("COALESCE(MAX(id), 0)").Scan(&maxID).Error
+	if err != nil {
+		appErr := apperrors.GetMaxIDErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	return maxID, nil
+}
+
+// DeleteWord removes the library word with its lemmas, its phrase links and
+// the progress of the users on it, all or nothing.
+func (rt *libraryRepository) DeleteWord(ctx context.Context, id int) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteUserWords(tx, id); err != nil {
+			return err
+		}
+
+		if err := deleteWordLemmas(tx, id); err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM library_phrases WHERE library_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id = ?", id).Delete(&models.Library{})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return result.Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		appErr := apperrors.DeleteWordErr.AppendMessage("no rows affected")
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	if err != nil {
+		appErr := apperrors.DeleteWordErr.AppendMessage(err)
 		rt.log.Error(appErr)
 		return appErr
 	}
@@ -217,24 +423,71 @@ func (rt *libraryRepository) InsertWordLibrary(ctx context.Context, word *models
 	return nil
 }
 
-func (rt *libraryRepository) UpdateWord(ctx context.Context, word *models.Library) error {
-	result := rt.db.Model(&models.Library{}).Where("id = ?", word.ID).
-		Updates(map[string]interface{}{
-			"english":         word.English,
-			"russian":         word.Russian,
-			"theme":           word.Theme,
-			"preposition":     word.Preposition,
-			"parts_of_speech": word.PartsOfSpeech,
-			"root":            word.Root,
-		})
+// MergeWords moves the user progress, the phrases and the extra lemmas of the
+// duplicates to the canonical word and deletes the duplicates with their
+// English and Russian lemmas, all or nothing.
+func (rt *libraryRepository) MergeWords(ctx context.Context, canonicalID int, duplicateIDs []int) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range duplicateIDs {
+			if err := mergeUserWords(tx, canonicalID, id); err != nil {
+				return err
+			}
+
+			if err := moveWordPhrases(tx, id, canonicalID); err != nil {
+				return err
+			}
+
+			if err := moveWordLemmas(tx, id, canonicalID); err != nil {
+				return err
+			}
+
+			if err := deleteWordLemmas(tx, id); err != nil {
+				return err
+			}
+
+			result := tx.Unscoped().Where("id = ?", id).Delete(&models.Library{})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return apperrors.MergeWordsErr.AppendMessage("there is no word with id", id)
+			}
+		}
+
+		return linkWordLemmas(tx, canonicalID)
+	})
+	if err != nil {
+		appErr := apperrors.MergeWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *libraryRepository) GetAllPartsOfSpeech() ([]string, error) {
+	var partsOfSpeech []string
+	err := rt.db.Table("libraries").Select("DISTINCT(parts_of_speech)").Pluck("DISTINCT(parts_of_speech)", &partsOfSpeech).Error
+	if err != nil {
+		appErr := apperrors.GetAllPartsOfSpeechErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return partsOfSpeech, nil
+}
+
+func (rt *libraryRepository) SetWordImage(ctx context.Context, id int, image string) error {
+	result := rt.db.WithContext(ctx).Model(&models.Library{}).Where("id = ?", id).Update("image", image)
 	if result.Error != nil {
-		appErr := apperrors.UpdateWordErr.AppendMessage(result.Error)
+		appErr := apperrors.SetWordImageErr.AppendMessage(result.Error)
 		rt.log.Error(appErr)
 		return appErr
 	}
 
 	if result.RowsAffected == 0 {
-		appErr := &apperrors.UpdateWordRowAffectedErr
+		appErr := apperrors.SetWordImageErr.AppendMessage("there is no word with id", id)
 		rt.log.Info(appErr)
 		return appErr
 	}
@@ -242,14 +495,28 @@ func (rt *libraryRepository) UpdateWord(ctx context.Context, word *models.Librar
 	return nil
 }
 
-func (rt *libraryRepository) GetAllTopics() ([]string, error) {
-	var themes []string
-	err := rt.db.Table("libraries").Select("DISTINCT(theme)").Pluck("DISTINCT(theme)", &themes).Error
+// SetWordImages attaches the pictures to the library words by word ID, all or
+// nothing.
+func (rt *libraryRepository) SetWordImages(ctx context.Context, images map[int]string) error {
+	err := rt.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, image := range images {
+			result := tx.Model(&models.Library{}).Where("id = ?", id).Update("image", image)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				return apperrors.SetWordImageErr.AppendMessage("there is no word with id", id)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		appErr := apperrors.GetAllTopicsErr.AppendMessage(err)
+		appErr := apperrors.SetWordImageErr.AppendMessage(err)
 		rt.log.Error(appErr)
-		return nil, appErr
+		return appErr
 	}
 
-	return themes, nil
+	return nil
 }
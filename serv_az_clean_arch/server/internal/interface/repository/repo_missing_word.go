@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type missingWordRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewMissingWordRepository(db *gorm.DB, log *logrus.Logger) repository.MissingWordRepository {
+	return &missingWordRepository{db: db, log: log}
+}
+
+// CountMissingWord adds a lookup to the word, putting it into the queue on
+// its first lookup. It is one MERGE, so concurrent first lookups of a word
+// don't race to insert it.
+func (rt *missingWordRepository) CountMissingWord(ctx context.Context, word *models.MissingWord) error {
+	now := time.Now()
+	err := rt.db.WithContext(ctx).Exec(`MERGE INTO missing_words WITH (HOLDLOCK) AS target
+		USING (VALUES (?, ?)) AS source (text, language)
+		ON target.text = source.text AND target.language = source.language
+		WHEN MATCHED THEN UPDATE SET lookups = target.lookups + 1, updated_at = ?
+		WHEN NOT MATCHED THEN INSERT (text, language, lookups, created_at, updated_at)
+			VALUES (source.text, source.language, 1, ?, ?);`,
+		word.Text, word.Language, now, now, now).Error
+	if err != nil {
+		appErr := apperrors.CountMissingWordErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// GetMissingWords returns the queue, the most looked up words first. Words
+// that have a lemma of their language by now are left out before the limit.
+func (rt *missingWordRepository) GetMissingWords(ctx context.Context, limit int) ([]*models.MissingWord, error) {
+	var words []*models.MissingWord
+	err := rt.db.WithContext(ctx).
+		Where(`NOT EXISTS (SELECT 1 FROM lemmas WHERE lemmas.deleted_at IS NULL
+			AND lemmas.language = missing_words.language AND LOWER(lemmas.text) = missing_words.text)`).
+		Order("lookups DESC, updated_at DESC").Limit(limit).Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetMissingWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return words, nil
+}
+
+func (rt *missingWordRepository) GetMissingWord(ctx context.Context, id int) (*models.MissingWord, error) {
+	var words []*models.MissingWord
+	if err := rt.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&words).Error; err != nil {
+		appErr := apperrors.GetMissingWordErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(words) == 0 {
+		appErr := apperrors.GetMissingWordErr.AppendMessage("there is no missing word with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return words[0], nil
+}
+
+func (rt *missingWordRepository) DeleteMissingWord(ctx context.Context, id int) error {
+	err := rt.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&models.MissingWord{}).Error
+	if err != nil {
+		appErr := apperrors.DeleteMissingWordErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
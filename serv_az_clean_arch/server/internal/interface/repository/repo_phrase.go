@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type phraseRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewPhraseRepository(db *gorm.DB, log *logrus.Logger) repository.PhraseRepository {
+	return &phraseRepository{db: db, log: log}
+}
+
+func (rt *phraseRepository) GetAllPhrases(ctx context.Context) ([]*models.Phrase, error) {
+	var phrases []*models.Phrase
+	err := rt.db.WithContext(ctx).Preload("Libraries", selectLibraryIDs).Order("id").Find(&phrases).Error
+	if err != nil {
+		appErr := apperrors.GetPhrasesErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return phrases, nil
+}
+
+func (rt *phraseRepository) GetPhrasesByWordIDs(ctx context.Context, wordIDs []int) ([]*models.Phrase, error) {
+	var phrases []*models.Phrase
+	if len(wordIDs) == 0 {
+		return phrases, nil
+	}
+
+	linked := rt.db.Table("library_phrases").Select("phrase_id").Where("library_id IN ?", wordIDs)
+	err := rt.db.WithContext(ctx).
+		Preload("Libraries", func(db *gorm.DB) *gorm.DB {
+			return selectLibraryIDs(db).Where("id IN ?", wordIDs)
+		}).
+		Where("id IN (?)", linked).
+		Find(&phrases).Error
+	if err != nil {
+		appErr := apperrors.GetPhrasesErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return phrases, nil
+}
+
+// UpsertPhrase writes the phrase under its ID and replaces its word links with
+// phrase.Libraries.
+func (rt *phraseRepository) UpsertPhrase(ctx context.Context, phrase *models.Phrase) error {
+	tx := rt.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		appErr := apperrors.UpsertPhraseErr.AppendMessage(tx.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	libraries := phrase.Libraries
+	phrase.Libraries = nil
+	defer func() { phrase.Libraries = libraries }()
+
+	result := tx.Model(&models.Phrase{}).Where("id = ?", phrase.ID).
+		Updates(map[string]interface{}{
+			"english": phrase.English,
+			"russian": phrase.Russian,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		appErr := apperrors.UpsertPhraseErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		if err := tx.Create(phrase).Error; err != nil {
+			tx.Rollback()
+			appErr := apperrors.UpsertPhraseErr.AppendMessage(err)
+			rt.log.Error(appErr)
+			return appErr
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM library_phrases WHERE phrase_id = ?", phrase.ID).Error; err != nil {
+		tx.Rollback()
+		appErr := apperrors.UpsertPhraseErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	for _, library := range libraries {
+		err := tx.Exec("INSERT INTO library_phrases (library_id, phrase_id) VALUES (?, ?)", library.ID, phrase.ID).Error
+		if err != nil {
+			tx.Rollback()
+			appErr := apperrors.UpsertPhraseErr.AppendMessage(err)
+			rt.log.Error(appErr)
+			return appErr
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		appErr := apperrors.UpsertPhraseErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// moveWordPhrases links the phrases of one word to another, used when
+// duplicate words are merged.
+func moveWordPhrases(tx *gorm.DB, fromID, toID int) error {
+	err := tx.Exec(`INSERT INTO library_phrases (library_id, phrase_id)
+		SELECT ?, phrase_id FROM library_phrases
+		WHERE library_id = ? AND phrase_id NOT IN (SELECT phrase_id FROM library_phrases WHERE library_id = ?)`,
+		toID, fromID, toID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec("DELETE FROM library_phrases WHERE library_id = ?", fromID).Error
+}
+
+func selectLibraryIDs(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "english")
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type topicRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewTopicRepository(db *gorm.DB, log *logrus.Logger) repository.TopicRepository {
+	return &topicRepository{db: db, log: log}
+}
+
+func (rt *topicRepository) GetAllTopics(ctx context.Context) ([]*models.Topic, error) {
+	var topics []*models.Topic
+	err := rt.db.WithContext(ctx).Order("sort_order").Order("title_en").Find(&topics).Error
+	if err != nil {
+		appErr := apperrors.GetTopicsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return topics, nil
+}
+
+func (rt *topicRepository) GetTopicByID(ctx context.Context, id int) (*models.Topic, error) {
+	return rt.getTopic(ctx, "id = ?", id)
+}
+
+func (rt *topicRepository) GetTopicBySlug(ctx context.Context, slug string) (*models.Topic, error) {
+	return rt.getTopic(ctx, "slug = ?", slug)
+}
+
+func (rt *topicRepository) getTopic(ctx context.Context, query string, arg interface{}) (*models.Topic, error) {
+	var topics []*models.Topic
+	err := rt.db.WithContext(ctx).Where(query, arg).Limit(1).Find(&topics).Error
+	if err != nil {
+		appErr := apperrors.GetTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(topics) == 0 {
+		appErr := apperrors.GetTopicErr.AppendMessage("there is no topic", arg)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return topics[0], nil
+}
+
+func (rt *topicRepository) CreateTopic(ctx context.Context, topic *models.Topic) error {
+	result := rt.db.WithContext(ctx).Create(topic)
+	if result.Error != nil {
+		appErr := apperrors.CreateTopicErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.CreateTopicErr.AppendMessage("no rows affected")
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// UpdateTopic also renames the theme of the words in the topic, so the
+// spreadsheet export keeps matching the topic title.
+func (rt *topicRepository) UpdateTopic(ctx context.Context, topic *models.Topic) error {
+	tx := rt.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		appErr := apperrors.UpdateTopicErr.AppendMessage(tx.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	result := tx.Model(&models.Topic{}).Where("id = ?", topic.ID).
+		Updates(map[string]interface{}{
+			"slug":           topic.Slug,
+			"title_en":       topic.TitleEn,
+			"title_ru":       topic.TitleRu,
+			"description_en": topic.DescriptionEn,
+			"description_ru": topic.DescriptionRu,
+			"parent_id":      topic.ParentID,
+			"sort_order":     topic.SortOrder,
+			"level":          topic.Level,
+		})
+	if result.Error != nil {
+		tx.Rollback()
+		appErr := apperrors.UpdateTopicErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		appErr := apperrors.UpdateTopicErr.AppendMessage("there is no topic with id", topic.ID)
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	err := tx.Model(&models.Library{}).Where("topic_id = ?", topic.ID).Update("theme", topic.TitleEn).Error
+	if err != nil {
+		tx.Rollback()
+		appErr := apperrors.UpdateTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	wordIDs := tx.Model(&models.Library{}).Select("id").Where("topic_id = ?", topic.ID)
+	err = tx.Model(&models.Word{}).Where("id IN (?)", wordIDs).Update("theme", topic.TitleEn).Error
+	if err != nil {
+		tx.Rollback()
+		appErr := apperrors.UpdateTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		appErr := apperrors.UpdateTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+// DeleteTopic moves the children of the topic up to its parent.
+func (rt *topicRepository) DeleteTopic(ctx context.Context, id int) error {
+	topic, err := rt.GetTopicByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tx := rt.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		appErr := apperrors.DeleteTopicErr.AppendMessage(tx.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	err = tx.Model(&models.Topic{}).Where("parent_id = ?", id).Update("parent_id", topic.ParentID).Error
+	if err != nil {
+		tx.Rollback()
+		appErr := apperrors.DeleteTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Unscoped().Where("id = ?", id).Delete(&models.Topic{}).Error; err != nil {
+		tx.Rollback()
+		appErr := apperrors.DeleteTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		appErr := apperrors.DeleteTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *topicRepository) CountTopicWords(ctx context.Context, id int) (int64, error) {
+	var count int64
+	err := rt.db.WithContext(ctx).Model(&models.Library{}).Where("topic_id = ?", id).Count(&count).Error
+	if err != nil {
+		appErr := apperrors.CountTopicWordsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return 0, appErr
+	}
+
+	return count, nil
+}
+
+func (rt *topicRepository) SetThemeTopic(ctx context.Context, theme string, topicID int) error {
+	err := rt.db.WithContext(ctx).Model(&models.Library{}).Where("theme = ?", theme).Update("topic_id", topicID).Error
+	if err != nil {
+		appErr := apperrors.SetThemeTopicErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
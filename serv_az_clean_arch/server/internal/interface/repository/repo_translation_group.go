@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type translationGroupRepository struct {
+	log *logrus.Logger
+	db  *gorm.DB
+}
+
+func NewTranslationGroupRepository(db *gorm.DB, log *logrus.Logger) repository.TranslationGroupRepository {
+	return &translationGroupRepository{db: db, log: log}
+}
+
+func (rt *translationGroupRepository) GetAllGroups(ctx context.Context) ([]*models.TranslationGroup, error) {
+	var groups []*models.TranslationGroup
+	err := rt.db.WithContext(ctx).Preload("Variants").Order("name").Find(&groups).Error
+	if err != nil {
+		appErr := apperrors.GetAllGroupsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	var words []*models.Library
+	err = rt.db.WithContext(ctx).Where("translation_group_id > 0").Order("english").Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetAllGroupsErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	groupsByID := make(map[int]*models.TranslationGroup, len(groups))
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	for _, word := range words {
+		if group, ok := groupsByID[word.TranslationGroupID]; ok {
+			word.TranslationGroupName = group.Name
+			group.Words = append(group.Words, word)
+		}
+	}
+
+	return groups, nil
+}
+
+func (rt *translationGroupRepository) GetGroupByID(ctx context.Context, id int) (*models.TranslationGroup, error) {
+	var groups []*models.TranslationGroup
+	err := rt.db.WithContext(ctx).Preload("Variants").Where("id = ?", id).Limit(1).Find(&groups).Error
+	if err != nil {
+		appErr := apperrors.GetGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(groups) == 0 {
+		appErr := apperrors.GetGroupErr.AppendMessage("there is no group with id", id)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return groups[0], nil
+}
+
+func (rt *translationGroupRepository) GetGroupByName(ctx context.Context, name string) (*models.TranslationGroup, error) {
+	var groups []*models.TranslationGroup
+	err := rt.db.WithContext(ctx).Where("name = ?", name).Limit(1).Find(&groups).Error
+	if err != nil {
+		appErr := apperrors.GetGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return nil, appErr
+	}
+
+	if len(groups) == 0 {
+		appErr := apperrors.GetGroupErr.AppendMessage("there is no group", name)
+		rt.log.Info(appErr)
+		return nil, appErr
+	}
+
+	return groups[0], nil
+}
+
+func (rt *translationGroupRepository) CreateGroup(ctx context.Context, group *models.TranslationGroup) error {
+	result := rt.db.WithContext(ctx).Create(group)
+	if result.Error != nil {
+		appErr := apperrors.CreateGroupErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.CreateGroupErr.AppendMessage("no rows affected")
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *translationGroupRepository) DeleteGroup(ctx context.Context, id int) error {
+	tx := rt.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		appErr := apperrors.DeleteGroupErr.AppendMessage(tx.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	err := tx.Model(&models.Library{}).Where("translation_group_id = ?", id).Update("translation_group_id", 0).Error
+	if err != nil {
+		tx.Rollback()
+		appErr := apperrors.DeleteGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Unscoped().Where("translation_group_id = ?", id).Delete(&models.TranslationVariant{}).Error; err != nil {
+		tx.Rollback()
+		appErr := apperrors.DeleteGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Unscoped().Where("id = ?", id).Delete(&models.TranslationGroup{}).Error; err != nil {
+		tx.Rollback()
+		appErr := apperrors.DeleteGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		appErr := apperrors.DeleteGroupErr.AppendMessage(err)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *translationGroupRepository) SetWordGroup(ctx context.Context, wordID, groupID int) error {
+	result := rt.db.WithContext(ctx).Model(&models.Library{}).Where("id = ?", wordID).Update("translation_group_id", groupID)
+	if result.Error != nil {
+		appErr := apperrors.SetWordGroupErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.SetWordGroupErr.AppendMessage("there is no word with id", wordID)
+		rt.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *translationGroupRepository) AddVariant(ctx context.Context, variant *models.TranslationVariant) error {
+	result := rt.db.WithContext(ctx).Create(variant)
+	if result.Error != nil {
+		appErr := apperrors.AddVariantErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.AddVariantErr.AppendMessage("no rows affected")
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (rt *translationGroupRepository) DeleteVariant(ctx context.Context, id int) error {
+	result := rt.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&models.TranslationVariant{})
+	if result.Error != nil {
+		appErr := apperrors.DeleteVariantErr.AppendMessage(result.Error)
+		rt.log.Error(appErr)
+		return appErr
+	}
+
+	return nil
+}
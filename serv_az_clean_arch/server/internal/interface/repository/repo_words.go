@@ -122,11 +122,18 @@ func (rt *wordsRepository) UpdateWords(ctx context.Context, words []*models.Word
 func (rt *wordsRepository) UpdateWord(ctx context.Context, word *models.Word) error {
 	result := rt.db.Model(&models.Word{}).Where("id = ?", word.ID).
 		Updates(map[string]interface{}{
-			"english":         word.English,
-			"russian":         word.Russian,
-			"theme":           word.Theme,
-			"preposition":     word.Preposition,
-			"parts_of_speech": word.PartsOfSpeech,
+			"english":          word.English,
+			"russian":          word.Russian,
+			"theme":            word.Theme,
+			"preposition":      word.Preposition,
+			"parts_of_speech":  word.PartsOfSpeech,
+			"level":            word.Level,
+			"frequency":        word.Frequency,
+			"transcription":    word.Transcription,
+			"russian_stressed": word.RussianStressed,
+			"forms":            word.Forms,
+			"register":         word.Register,
+			"usage_note":       word.UsageNote,
 		})
 	if result.Error != nil {
 		appErr := apperrors.UpdateWordErr.AppendMessage(word.English, " word ", result.Error)
@@ -154,3 +161,56 @@ func (rt *wordsRepository) GetAllTopics() ([]string, error) {
 
 	return themes, nil
 }
+
+var userWordTables = []string{"user_words", "user_learn", "user_learned"}
+
+// deleteUserWords removes the word from the words table, from the progress of
+// every user and from their decks, and unlinks the custom words made from it.
+func deleteUserWords(tx *gorm.DB, id int) error {
+	for _, table := range userWordTables {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE word_id = ?", id).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM deck_words WHERE word_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("UPDATE custom_words SET library_id = 0 WHERE library_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Where("id = ?", id).Delete(&models.Word{}).Error
+}
+
+// mergeUserWords points the progress rows and the deck entries of the
+// duplicate at the canonical word, drops the rows of users and decks that have
+// the canonical word already and removes the duplicate from the words table.
+// Custom words linked to the duplicate are linked to the canonical word.
+func mergeUserWords(tx *gorm.DB, canonicalID, duplicateID int) error {
+	for _, table := range userWordTables {
+		err := tx.Exec("UPDATE "+table+" SET word_id = ? WHERE word_id = ? AND user_id NOT IN (SELECT user_id FROM "+table+" WHERE word_id = ?)",
+			canonicalID, duplicateID, canonicalID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tx.Exec("UPDATE deck_words SET word_id = ? WHERE word_id = ? AND deck_id NOT IN (SELECT deck_id FROM deck_words WHERE word_id = ?)",
+		canonicalID, duplicateID, canonicalID).Error
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Exec("UPDATE custom_words SET library_id = ? WHERE library_id = ?", canonicalID, duplicateID).Error; err != nil {
+		return err
+	}
+
+	if err := deleteUserWords(tx, duplicateID); err != nil {
+		return err
+	}
+
+	return tx.Exec("DELETE FROM user_words WHERE word_id = ? AND user_id IN (SELECT user_id FROM user_learned WHERE word_id = ?)",
+		canonicalID, canonicalID).Error
+}
@@ -15,6 +15,28 @@ import (
 	"gorm.io/gorm"
 )
 
+// wordsByCourseOrder serves new words up to the user's course level first,
+// from the easiest level and the most frequent word; words without a level or
+// frequency rank go last.
+var wordsByCourseOrder = "CASE WHEN " + levelRank("words.level") + " > 0 AND " + levelRank("words.level") + " <= " + levelRank("users.level") + " THEN 0 " +
+	"WHEN " + levelRank("words.level") + " > 0 THEN 1 ELSE 2 END, " + wordsByLevelOrder
+
+// wordsByLevelOrder serves words from the easiest level and the most frequent
+// word; words without a level or frequency rank go last.
+var wordsByLevelOrder = "CASE WHEN " + levelRank("words.level") + " > 0 THEN 0 ELSE 1 END, " + levelRank("words.level") + ", " +
+	"CASE WHEN words.frequency > 0 THEN 0 ELSE 1 END, words.frequency, words.id"
+
+// levelRank is the SQL rank of the level in the column, its place in
+// models.CEFRLevels counted from 1, or 0 for other values.
+func levelRank(column string) string {
+	rank := "CASE " + column
+	for i, level := range models.CEFRLevels {
+		rank += fmt.Sprintf(" WHEN '%s' THEN %d", level, i+1)
+	}
+
+	return rank + " ELSE 0 END"
+}
+
 type userRepository struct {
 	log *logrus.Logger
 	db  *gorm.DB
@@ -182,6 +204,33 @@ func (usr *userRepository) AddWordToLearn(ctx context.Context, user *models.User
 	return nil
 }
 
+// addWordsToLearn puts the words into the learn queue, skipping the ones
+// already there.
+func addWordsToLearn(tx *gorm.DB, userID string, wordIDs []int) error {
+	for _, wordID := range wordIDs {
+		err := tx.Exec(`INSERT INTO user_learn (user_id, word_id) SELECT ?, ?
+			WHERE NOT EXISTS (SELECT 1 FROM user_learn WHERE user_id = ? AND word_id = ?)`,
+			userID, wordID, userID, wordID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (usr *userRepository) GetLearnedWordIDs(ctx context.Context, id *uuid.UUID) ([]int, error) {
+	var ids []int
+	err := usr.db.WithContext(ctx).Table("user_learned").Where("user_id = ?", id).Pluck("word_id", &ids).Error
+	if err != nil {
+		appErr := apperrors.GetLearnedWordIDsErr.AppendMessage(err)
+		usr.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return ids, nil
+}
+
 func (usr *userRepository) UpdateUser(ctx context.Context, user *models.User) error {
 	tx := usr.db.Begin()
 	if tx.Error != nil {
@@ -229,6 +278,61 @@ func (usr *userRepository) UpdateUserById(ctx context.Context, userReq *requests
 	return nil
 }
 
+func (usr *userRepository) UpdateUserLevel(ctx context.Context, userID, level string) error {
+	result := usr.db.Model(&models.User{}).Where("id = ?", userID).Update("level", level)
+	if result.Error != nil {
+		appErr := apperrors.UpdateUserLevelErr.AppendMessage(result.Error)
+		usr.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.UpdateUserLevelErr.AppendMessage("there is no user with id", userID)
+		usr.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (usr *userRepository) UpdateUserLanguages(ctx context.Context, userID string, pair models.LanguagePair) error {
+	result := usr.db.Model(&models.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"source_language": pair.Source,
+			"target_language": pair.Target,
+		})
+	if result.Error != nil {
+		appErr := apperrors.UpdateUserLanguagesErr.AppendMessage(result.Error)
+		usr.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.UpdateUserLanguagesErr.AppendMessage("there is no user with id", userID)
+		usr.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
+func (usr *userRepository) UpdateUserKeepHistory(ctx context.Context, userID string, keep bool) error {
+	result := usr.db.Model(&models.User{}).Where("id = ?", userID).Update("keep_history", keep)
+	if result.Error != nil {
+		appErr := apperrors.UpdateUserKeepHistoryErr.AppendMessage(result.Error)
+		usr.log.Error(appErr)
+		return appErr
+	}
+
+	if result.RowsAffected == 0 {
+		appErr := apperrors.UpdateUserKeepHistoryErr.AppendMessage("there is no user with id", userID)
+		usr.log.Info(appErr)
+		return appErr
+	}
+
+	return nil
+}
+
 func (usr *userRepository) UpdateUserPasswordById(ctx context.Context, userID, newPass string) error {
 	result := usr.db.Model(&models.User{}).Where("id = ?", userID).
 		Updates(map[string]interface{}{
@@ -251,9 +355,15 @@ func (usr *userRepository) UpdateUserPasswordById(ctx context.Context, userID, n
 
 func (usr *userRepository) GetWordsByIDAndLimit(ctx context.Context, id *uuid.UUID, limit int) ([]*models.Word, error) {
 	var words []*models.Word
-	subQuery := usr.db.Table("user_words").Select("word_id").Where("user_id = ?", id).Limit(limit)
-
-	err := usr.db.Table("words").Where("id IN (?)", subQuery).Find(&words).Error
+	err := usr.db.
+		WithContext(ctx).
+		Select("words.*").
+		Joins("JOIN user_words ON user_words.word_id = words.id").
+		Joins("JOIN users ON users.id = user_words.user_id").
+		Where("user_words.user_id = ?", id).
+		Order(wordsByCourseOrder).
+		Limit(limit).
+		Find(&words).Error
 	if err != nil {
 		appErr := apperrors.GetWordsByIDAndLimitErr.AppendMessage(err)
 		usr.log.Error(appErr)
@@ -264,14 +374,40 @@ func (usr *userRepository) GetWordsByIDAndLimit(ctx context.Context, id *uuid.UU
 	return words, nil
 }
 
-func (usr *userRepository) GetWordsByUserIdAndLimitAndTopic(ctx context.Context, id *uuid.UUID, limit int, topic string) ([]*models.Word, error) {
+// GetWordsWithImageByIDAndLimit returns the user's words that have a picture
+// in the library, with the picture name set.
+func (usr *userRepository) GetWordsWithImageByIDAndLimit(ctx context.Context, id *uuid.UUID, limit int) ([]*models.Word, error) {
+	words := []*models.Word{}
+	err := usr.db.
+		WithContext(ctx).
+		Select("words.*", "libraries.image").
+		Joins("JOIN user_words ON user_words.word_id = words.id").
+		Joins("JOIN libraries ON libraries.id = words.id").
+		Joins("JOIN users ON users.id = user_words.user_id").
+		Where("user_words.user_id = ? AND libraries.image <> ''", id).
+		Order(wordsByCourseOrder).
+		Limit(limit).
+		Find(&words).Error
+	if err != nil {
+		appErr := apperrors.GetWordsWithImageErr.AppendMessage(err)
+		usr.log.Error(appErr)
+		return nil, appErr
+	}
+
+	return words, nil
+}
+
+func (usr *userRepository) GetWordsByUserIdAndLimitAndTopic(ctx context.Context, id *uuid.UUID, limit int, topicIDs []int) ([]*models.Word, error) {
 	words := []*models.Word{}
 	err := usr.db.
-		Unscoped().
-		Table("user_words").
-		Select("id, english, russian, theme, parts_of_speech", "created_at", "updated_at").
-		Joins("JOIN words ON words.id = user_words.word_id").
-		Where("user_words.user_id = ? AND words.theme = ?", id, topic). //"Irregular verb"
+		WithContext(ctx).
+		Select("words.id, words.english, words.russian, words.theme, words.parts_of_speech, words.level, words.frequency",
+			"words.transcription, words.russian_stressed, words.forms, words.register, words.usage_note", "words.created_at", "words.updated_at").
+		Joins("JOIN user_words ON user_words.word_id = words.id").
+		Joins("JOIN libraries ON libraries.id = words.id").
+		Joins("JOIN users ON users.id = user_words.user_id").
+		Where("user_words.user_id = ? AND libraries.topic_id IN ?", id, topicIDs).
+		Order(wordsByCourseOrder).
 		Limit(limit).
 		Find(&words).Error
 
@@ -46,11 +46,40 @@ func (r *registry) NewHandlersController() controller.HandleController {
 	libInteractor := interactor.NewLibraryInteractor(
 		repository.NewLibraryRepository(r.db, r.log),
 		repository.NewWordsRepository(r.db, r.log),
-		repository.NewBackUpCopyRepo(backupXLS, r.log),
+		repository.NewBackUpCopyRepo(r.config.Server.LibraryBackupPath, r.log),
+		repository.NewTranslationGroupRepository(r.db, r.log),
+		repository.NewTopicRepository(r.db, r.log),
+		repository.NewPhraseRepository(r.db, r.log),
+		repository.NewImageRepository(r.config.Server.ImagesPath, r.log),
+		repository.NewLemmaRepository(r.db, r.log),
+	)
+	deckInteractor := interactor.NewDeckInteractor(
+		repository.NewDeckRepository(r.db, r.log),
+		repository.NewUserRepository(r.db, r.log),
+		libInteractor,
 	)
-	comparr := comparer.NewComparer(libInteractor, userInteractor, r.log)
 
-	return controller.NewHandlersController(comparr, userInteractor, libInteractor, r.hashDB, r.log, r.config, r.tmpls)
-}
+	comparr := comparer.NewComparer(libInteractor, userInteractor, deckInteractor, r.log)
+	disputeInteractor := interactor.NewDisputeInteractor(
+		repository.NewDisputeRepository(r.db, r.log),
+		libInteractor,
+		userInteractor,
+	)
+
+	historyInteractor := interactor.NewHistoryInteractor(
+		repository.NewHistoryRepository(r.db, r.log),
+		repository.NewUserRepository(r.db, r.log),
+	)
+
+	missingWordInteractor := interactor.NewMissingWordInteractor(
+		repository.NewMissingWordRepository(r.db, r.log),
+		libInteractor,
+	)
 
-const backupXLS = "save_copy/library.xlsx"
+	correctionInteractor := interactor.NewCorrectionInteractor(
+		repository.NewCorrectionRepository(r.db, r.log),
+		libInteractor,
+	)
+
+	return controller.NewHandlersController(comparr, userInteractor, libInteractor, disputeInteractor, deckInteractor, historyInteractor, missingWordInteractor, correctionInteractor, r.hashDB, r.log, r.config, r.tmpls)
+}
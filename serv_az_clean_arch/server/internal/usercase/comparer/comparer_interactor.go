@@ -1,6 +1,7 @@
 package comparer
 
 import (
+	"context"
 	"net/http"
 	"server/internal/apperrors"
 	"server/internal/domain/models"
@@ -14,41 +15,98 @@ import (
 )
 
 // i think this is a bad way to save some data, it's better to use reddis or some hashDB
-var HashTableWords = make(map[string]*models.TestPageData)
-var HashTableWordsLearn = make(map[string]*models.TestPageData)
+var HashTableWords = NewSessions()
+var HashTableWordsLearn = NewSessions()
+var HashTableCloze = NewSessions()
+var HashTablePictures = NewSessions()
 
 type Comparer interface {
 	CompareTestWords(r *http.Request, userID string) error
 	CompareLearnWords(r *http.Request, userID string) error
+	CompareClozeWords(r *http.Request, userID string) error
+	ComparePictureWords(r *http.Request, userID string) error
+	CompareTestAnswers(ctx context.Context, userID string, answers []string) error
+	CompareLearnAnswers(ctx context.Context, userID string, answers []string) error
 }
 
 type comparer struct {
 	LibraryInteractor interactor.LibraryInteractor
 	UserInteractor    interactor.UserInteractor
+	DeckInteractor    interactor.DeckInteractor
 	log               *logrus.Logger
 }
 
 func NewComparer(LibraryInteractor interactor.LibraryInteractor,
-	UserInteractor interactor.UserInteractor, log *logrus.Logger) Comparer {
+	UserInteractor interactor.UserInteractor, DeckInteractor interactor.DeckInteractor, log *logrus.Logger) Comparer {
 	return &comparer{
 		LibraryInteractor: LibraryInteractor,
 		UserInteractor:    UserInteractor,
+		DeckInteractor:    DeckInteractor,
 		log:               log,
 	}
 }
 
 func (srv comparer) CompareTestWords(r *http.Request, userID string) error {
+	pageData, ok := HashTableWords.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there is no test to check")
+	}
+
+	return srv.CompareTestAnswers(r.Context(), userID, formAnswers(r, len(pageData.Words)))
+}
+
+// CompareTestAnswers checks the answers to the user's test in the order of
+// its words. Missing answers are wrong.
+func (srv comparer) CompareTestAnswers(ctx context.Context, userID string, answers []string) error {
+	pageData, ok := HashTableWords.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there is no test to check")
+	}
+
+	return srv.compareTest(ctx, userID, HashTableWords, pageData, answers)
+}
+
+func (srv comparer) CompareClozeWords(r *http.Request, userID string) error {
+	pageData, ok := HashTableCloze.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there is no cloze test to check")
+	}
+
+	return srv.compareTest(r.Context(), userID, HashTableCloze, pageData, formAnswers(r, len(pageData.Words)))
+}
+
+func (srv comparer) ComparePictureWords(r *http.Request, userID string) error {
+	pageData, ok := HashTablePictures.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there is no picture test to check")
+	}
+
+	return srv.compareTest(r.Context(), userID, HashTablePictures, pageData, formAnswers(r, len(pageData.Words)))
+}
+
+// compareTest grades the answers to the words of the test and moves each word
+// to the user's learned words or to the learn queue. The graded copy of the
+// test replaces it in sessions.
+func (srv comparer) compareTest(ctx context.Context, userID string, sessions *Sessions, pageData *models.TestPageData, answers []string) error {
+	graded := cloneSession(pageData)
 	result := models.TestResult{}
-	for i, word := range HashTableWords[userID].Words {
-		answer := r.FormValue("answer" + strconv.Itoa(i))
+	for i, word := range graded.Words {
+		answer := answerAt(answers, i)
 		//srv.log.Infof("word [%v] and answer [%v]", word, answer)
+		word.Answer = answer
 
 		wordId := strconv.Itoa(word.ID)
-		if srv.compare(word, answer) {
+		if srv.compare(word, answer, pageData.Pair) {
 			//srv.log.Infof("IF COMPARE word [%v] and answer [%v]", word, answer)
-			HashTableWords[userID].Words[i].Right = true
+			word.Right = true
+
+			var err error
+			if word.CustomID > 0 {
+				err = srv.DeckInteractor.CustomWordAnswered(ctx, userID, word.CustomID, true)
+			} else {
+				err = srv.UserInteractor.MoveWordToLearned(ctx, userID, wordId)
+			}
 
-			err := srv.UserInteractor.MoveWordToLearned(r.Context(), userID, wordId)
 			if err != nil {
 				appErr := err.(*apperrors.AppError)
 				srv.log.Error(appErr)
@@ -58,7 +116,13 @@ func (srv comparer) CompareTestWords(r *http.Request, userID string) error {
 			result.Right++
 		} else {
 			//srv.log.Infof("ELSE word [%v] and answer [%v]", word, answer)
-			err := srv.UserInteractor.AddWordToLearn(r.Context(), userID, wordId)
+			var err error
+			if word.CustomID > 0 {
+				err = srv.DeckInteractor.CustomWordAnswered(ctx, userID, word.CustomID, false)
+			} else {
+				err = srv.UserInteractor.AddWordToLearn(ctx, userID, wordId)
+			}
+
 			if err != nil {
 				appErr := err.(*apperrors.AppError)
 				srv.log.Error(appErr)
@@ -70,19 +134,44 @@ func (srv comparer) CompareTestWords(r *http.Request, userID string) error {
 
 	}
 
-	HashTableWords[userID].Result = &result
-	HashTableWords[userID].TestPassed = true
+	graded.Result = &result
+	graded.TestPassed = true
+	if !sessions.swap(userID, pageData, graded) {
+		return apperrors.TestSessionErr.AppendMessage("the test has been restarted or checked meanwhile")
+	}
 
 	return nil
 }
 
 func (srv comparer) CompareLearnWords(r *http.Request, userID string) error {
+	pageData, ok := HashTableWordsLearn.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there are no words to learn")
+	}
+
+	return srv.CompareLearnAnswers(r.Context(), userID, formAnswers(r, len(pageData.Words)))
+}
+
+// CompareLearnAnswers checks the answers to the user's learn session and
+// keeps the words answered wrong for the next round.
+func (srv comparer) CompareLearnAnswers(ctx context.Context, userID string, answers []string) error {
+	pageData, ok := HashTableWordsLearn.Get(userID)
+	if !ok {
+		return apperrors.TestSessionErr.AppendMessage("there are no words to learn")
+	}
+
+	next := cloneSession(pageData)
 	words := []*models.Word{}
-	for i, word := range HashTableWordsLearn[userID].Words {
-		answer := r.FormValue("answer" + strconv.Itoa(i))
+	for i, word := range next.Words {
+		answer := answerAt(answers, i)
 		if srv.compareToLoverAndIgnoreSpace(word.English, answer) {
-			wordId := strconv.Itoa(word.ID)
-			err := srv.UserInteractor.DeleteLearnFromUserById(r.Context(), userID, wordId)
+			var err error
+			if word.CustomID > 0 {
+				err = srv.DeckInteractor.CustomWordLearned(ctx, userID, word.CustomID)
+			} else {
+				err = srv.UserInteractor.DeleteLearnFromUserById(ctx, userID, strconv.Itoa(word.ID))
+			}
+
 			if err != nil {
 				appErr := err.(*apperrors.AppError)
 				srv.log.Error(appErr)
@@ -93,35 +182,64 @@ func (srv comparer) CompareLearnWords(r *http.Request, userID string) error {
 		}
 	}
 
-	HashTableWordsLearn[userID].Words = words
-
-	if len(words) == 0 {
-		HashTableWordsLearn[userID].LearnPassed = true
+	next.Words = words
+	next.LearnPassed = len(words) == 0
+	if !HashTableWordsLearn.swap(userID, pageData, next) {
+		return apperrors.TestSessionErr.AppendMessage("the words have been restarted or checked meanwhile")
 	}
 
 	return nil
 }
 
+// formAnswers reads the answers of the test forms, named answer0, answer1...
+func formAnswers(r *http.Request, count int) []string {
+	answers := make([]string, count)
+	for i := range answers {
+		answers[i] = r.FormValue("answer" + strconv.Itoa(i))
+	}
+
+	return answers
+}
+
+func answerAt(answers []string, i int) string {
+	if i < len(answers) {
+		return answers[i]
+	}
+
+	return ""
+}
+
 func (srv comparer) compareToLoverAndIgnoreSpace(word string, answer string) bool {
 	wordEnglEgnoredSpaceLoverCase := strings.ToLower(ignorSpace(word))
 	answerIgnoredSpaceLoverCase := strings.ToLower(ignorSpace(answer))
 	return strings.EqualFold(wordEnglEgnoredSpaceLoverCase, answerIgnoredSpaceLoverCase)
 }
 
-func (srv comparer) compare(word *models.Word, answer string) bool {
-	wordEnglEgnoredSpaceLoverCase := strings.ToLower(ignorSpace(word.English))
+func (srv comparer) compare(word *models.Word, answer string, pair models.LanguagePair) bool {
+	expected := word.English
+	if word.ClozeAnswer != "" {
+		expected = word.ClozeAnswer
+	}
+
+	wordEnglEgnoredSpaceLoverCase := strings.ToLower(ignorSpace(expected))
 	answerIgnoredSpaceLoverCase := strings.ToLower(ignorSpace(answer))
 	if strings.EqualFold(wordEnglEgnoredSpaceLoverCase, answerIgnoredSpaceLoverCase) {
 		//srv.log.Infof("if strings.EqualFold word [%v] and answer [%v]", word, answer)
 		return true
 	}
 
-	if srv.compareWithMap(word.Russian, answerIgnoredSpaceLoverCase, repository.WordsLibraryLocalMap) {
-		//srv.log.Infof("if compaRE MAP word [%v] and answer [%v]", word, answer)
-		return true
+	if !pair.IsDefault() {
+		if srv.compareWithLemmas(word.ID, pair.Target, answerIgnoredSpaceLoverCase, repository.LemmasLocalMap.Load()) {
+			return true
+		}
+
+		// translation groups hold English answers only
+		return pair.Target == models.LangEnglish && srv.compareWithGroup(word.ID, answerIgnoredSpaceLoverCase, repository.TranslationGroupsLocalMap.Load())
 	}
 
-	return false
+	// other English words with the same Russian aren't accepted by
+	// coincidence, only the translation group links answers
+	return srv.compareWithGroup(word.ID, answerIgnoredSpaceLoverCase, repository.TranslationGroupsLocalMap.Load())
 }
 
 func (srv comparer) compareStringsLevenshtein(str1, str2 string) bool {
@@ -145,13 +263,28 @@ func ignorSpace(s string) (c string) {
 	return
 }
 
-func (srv comparer) compareWithMap(russian, answerIgnoredSpaceLoverCase string, mapWords *map[string][]string) bool {
-	englishWords, ok := (*mapWords)[russian]
-	if !ok {
+func (srv comparer) compareWithGroup(wordID int, answerIgnoredSpaceLoverCase string, groups *map[int][]string) bool {
+	if groups == nil {
+		return false
+	}
+
+	for _, word := range (*groups)[wordID] {
+		if srv.compareStringsLevenshtein(answerIgnoredSpaceLoverCase, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareWithLemmas accepts any lemma of the word in the target language,
+// linked translations included.
+func (srv comparer) compareWithLemmas(wordID int, language, answerIgnoredSpaceLoverCase string, lemmas *map[int]map[string][]string) bool {
+	if lemmas == nil {
 		return false
 	}
 
-	for _, word := range englishWords {
+	for _, word := range (*lemmas)[wordID][language] {
 		if srv.compareStringsLevenshtein(answerIgnoredSpaceLoverCase, word) {
 			return true
 		}
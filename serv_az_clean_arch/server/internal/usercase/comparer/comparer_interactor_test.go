@@ -0,0 +1,34 @@
+package comparer
+
+import (
+	"server/internal/domain/models"
+	"server/internal/interface/repository"
+	"testing"
+)
+
+func TestCompareDefaultPair(t *testing.T) {
+	groups := map[int][]string{1: {"Big", "Large", "Huge"}}
+	repository.TranslationGroupsLocalMap.Store(&groups)
+
+	tests := []struct {
+		name   string
+		word   *models.Word
+		answer string
+		want   bool
+	}{
+		{name: "the word itself", word: &models.Word{ID: 1, English: "Big", Russian: "Большой"}, answer: " big ", want: true},
+		{name: "group answer", word: &models.Word{ID: 1, English: "Big", Russian: "Большой"}, answer: "huge", want: true},
+		{name: "group answer with a typo", word: &models.Word{ID: 1, English: "Big", Russian: "Большой"}, answer: "larg", want: true},
+		{name: "same russian without a group", word: &models.Word{ID: 2, English: "Great", Russian: "Большой"}, answer: "big", want: false},
+		{name: "wrong answer", word: &models.Word{ID: 1, English: "Big", Russian: "Большой"}, answer: "small", want: false},
+	}
+
+	srv := comparer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := srv.compare(tt.word, tt.answer, models.DefaultLanguagePair); got != tt.want {
+				t.Errorf("compare(%q) = %v, want %v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
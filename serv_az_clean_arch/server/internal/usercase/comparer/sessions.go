@@ -0,0 +1,121 @@
+package comparer
+
+import (
+	"server/internal/domain/models"
+	"sync"
+)
+
+// Sessions holds the live test of every user. A stored session isn't changed
+// afterwards: checking it stores a graded copy in its place, so a request
+// rendering a session never sees it half graded.
+type Sessions struct {
+	mu       sync.RWMutex
+	sessions map[string]*models.TestPageData
+}
+
+func NewSessions() *Sessions {
+	return &Sessions{sessions: make(map[string]*models.TestPageData)}
+}
+
+func (s *Sessions) Get(userID string) (*models.TestPageData, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pageData, ok := s.sessions[userID]
+	return pageData, ok
+}
+
+func (s *Sessions) Set(userID string, pageData *models.TestPageData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[userID] = pageData
+}
+
+// swap stores next in place of prev, unless another request has replaced
+// prev meanwhile.
+func (s *Sessions) swap(userID string, prev, next *models.TestPageData) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sessions[userID] != prev {
+		return false
+	}
+
+	s.sessions[userID] = next
+	return true
+}
+
+// cloneSession copies the session with its words and result, to be graded
+// without touching the stored one.
+func cloneSession(pageData *models.TestPageData) *models.TestPageData {
+	clone := *pageData
+	clone.Words = make([]*models.Word, len(pageData.Words))
+	for i, word := range pageData.Words {
+		copied := *word
+		clone.Words[i] = &copied
+	}
+
+	if pageData.Result != nil {
+		result := *pageData.Result
+		clone.Result = &result
+	}
+
+	return &clone
+}
+
+// checkedSessions returns the tables holding tests whose answers can be
+// disputed.
+func checkedSessions() []*Sessions {
+	return []*Sessions{HashTableWords, HashTableCloze, HashTablePictures}
+}
+
+// AnsweredWord finds the library word with the id among the answers of the
+// user's checked tests, with the language pair it was asked in.
+func AnsweredWord(userID string, wordID int) (*models.Word, models.LanguagePair, bool) {
+	for _, sessions := range checkedSessions() {
+		pageData, ok := sessions.Get(userID)
+		if !ok || pageData.Result == nil {
+			continue
+		}
+
+		for _, word := range pageData.Words {
+			if word.ID == wordID && word.CustomID == 0 {
+				return word, pageData.Pair, true
+			}
+		}
+	}
+
+	return nil, models.LanguagePair{}, false
+}
+
+// AcceptAnswer re-grades the answer to the word as right in the user's
+// checked tests, once a dispute of it has been accepted. It runs in the
+// admin's request, so it stores re-graded copies and leaves the sessions the
+// user's requests hold alone.
+func AcceptAnswer(userID string, wordID int, answer string) {
+	for _, sessions := range checkedSessions() {
+		pageData, ok := sessions.Get(userID)
+		if !ok || pageData.Result == nil {
+			continue
+		}
+
+		regraded := cloneSession(pageData)
+		changed := false
+		for _, word := range regraded.Words {
+			if word.ID != wordID || word.CustomID > 0 || word.Right {
+				continue
+			}
+
+			word.Answer = answer
+			word.Right = true
+			regraded.Result.Right++
+			regraded.Result.Wrong--
+			changed = true
+		}
+
+		if changed {
+			sessions.swap(userID, pageData, regraded)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package comparer
+
+import (
+	"server/internal/domain/models"
+	"testing"
+)
+
+func TestAcceptAnswer(t *testing.T) {
+	held := &models.TestPageData{
+		Words: []*models.Word{
+			{ID: 1, English: "Big", Answer: "large"},
+			{ID: 2, English: "Cat", Answer: "cat", Right: true},
+		},
+		Result:     &models.TestResult{Right: 1, Wrong: 1},
+		TestPassed: true,
+	}
+	HashTableWords.Set("user", held)
+
+	AcceptAnswer("user", 1, "large")
+
+	if held.Words[0].Right || held.Result.Right != 1 {
+		t.Errorf("AcceptAnswer() changed the session held by the user's request")
+	}
+
+	regraded, _ := HashTableWords.Get("user")
+	if !regraded.Words[0].Right || regraded.Result.Right != 2 || regraded.Result.Wrong != 0 {
+		t.Errorf("AcceptAnswer() stored words = %+v, result = %+v", regraded.Words[0], regraded.Result)
+	}
+
+	restarted := &models.TestPageData{}
+	HashTableWords.Set("user", restarted)
+	if HashTableWords.swap("user", regraded, held) {
+		t.Errorf("swap() replaced a session started meanwhile")
+	}
+}
@@ -0,0 +1,164 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// maxEPUBText bounds the unpacked size of the book's documents, so a small
+// archive can't unpack into gigabytes.
+const maxEPUBText = 64 << 20
+
+var errEPUBTooLarge = errors.New("the book is too large")
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Items []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// epubText reads the documents of the book in reading order.
+func epubText(data []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	budget := int64(maxEPUBText)
+	var container epubContainer
+	if err := readXML(files["META-INF/container.xml"], &budget, &container); err != nil {
+		return "", err
+	}
+
+	if len(container.Rootfiles) == 0 {
+		return "", errors.New("the book has no package document")
+	}
+
+	opfPath := container.Rootfiles[0].FullPath
+	var pkg epubPackage
+	if err := readXML(files[opfPath], &budget, &pkg); err != nil {
+		return "", err
+	}
+
+	hrefs := make(map[string]string, len(pkg.Items))
+	for _, item := range pkg.Items {
+		if strings.Contains(item.MediaType, "html") {
+			hrefs[item.ID] = item.Href
+		}
+	}
+
+	var text strings.Builder
+	for _, itemref := range pkg.Spine {
+		href, ok := hrefs[itemref.IDRef]
+		if !ok {
+			continue
+		}
+
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+
+		content, err := readFile(files[path.Join(path.Dir(opfPath), href)], &budget)
+		if err != nil {
+			return "", err
+		}
+
+		text.WriteString(htmlText(content))
+		text.WriteString("\n")
+	}
+
+	return text.String(), nil
+}
+
+func readXML(file *zip.File, budget *int64, v interface{}) error {
+	content, err := readFile(file, budget)
+	if err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(content, v)
+}
+
+func readFile(file *zip.File, budget *int64) ([]byte, error) {
+	if file == nil {
+		return nil, errors.New("the book is missing a file")
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, *budget+1))
+	if err != nil {
+		return nil, err
+	}
+
+	*budget -= int64(len(content))
+	if *budget < 0 {
+		return nil, errEPUBTooLarge
+	}
+
+	return content, nil
+}
+
+// htmlText returns the text of an XHTML document without scripts and styles.
+// The decoder is lenient since books are often not well formed.
+func htmlText(content []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var text strings.Builder
+	skip := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "script" || t.Name.Local == "style" {
+				skip++
+			}
+		case xml.EndElement:
+			if (t.Name.Local == "script" || t.Name.Local == "style") && skip > 0 {
+				skip--
+			}
+
+			text.WriteString(" ")
+		case xml.CharData:
+			if skip == 0 {
+				text.Write(t)
+			}
+		}
+	}
+
+	return text.String()
+}
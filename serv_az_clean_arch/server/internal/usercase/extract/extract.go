@@ -0,0 +1,29 @@
+// Package extract pulls plain text out of subtitle files and EPUB books.
+package extract
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+const (
+	KindSubtitles = "subtitles"
+	KindBook      = "book"
+)
+
+var ErrUnsupportedFormat = errors.New("only .srt, .vtt and .epub files are supported")
+
+// Text returns the text of the file and whether it is subtitles or a book,
+// the format is told by the file extension.
+func Text(filename string, data []byte) (string, string, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".srt", ".vtt":
+		return subtitlesText(data), KindSubtitles, nil
+	case ".epub":
+		text, err := epubText(data)
+		return text, KindBook, err
+	}
+
+	return "", "", ErrUnsupportedFormat
+}
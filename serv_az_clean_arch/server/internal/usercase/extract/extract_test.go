@@ -0,0 +1,139 @@
+package extract
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSubtitlesText(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     string
+	}{
+		{
+			name:     "srt",
+			filename: "movie.srt",
+			data: "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>Hello</i> there!\r\n\r\n" +
+				"2\r\n00:00:03,000 --> 00:00:04,000\r\n{\\an8}General Kenobi.\r\nYou are a bold one.\r\n",
+			want: "Hello there!\nGeneral Kenobi.\nYou are a bold one.",
+		},
+		{
+			name:     "vtt",
+			filename: "movie.VTT",
+			data: "WEBVTT - title\n\nNOTE a comment\nthat spans lines\n\nSTYLE\n::cue { color: red }\n\n" +
+				"intro\n00:01.000 --> 00:02.000 align:start\n<v Bob>Good morning</v>\n\n" +
+				"00:03.000 --> 00:04.000\n<b></b>\n\n00:05.000 --> 00:06.000\nSee you\n",
+			want: "intro\nGood morning\nSee you",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, kind, err := Text(tt.filename, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("Text(%q) error: %v", tt.filename, err)
+			}
+
+			if text != tt.want || kind != KindSubtitles {
+				t.Errorf("Text(%q) = %q %q, want %q %q", tt.filename, text, kind, tt.want, KindSubtitles)
+			}
+		})
+	}
+}
+
+func TestEPUBText(t *testing.T) {
+	container := `<?xml version="1.0"?>
+<container xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+	opf := `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf">
+  <manifest>
+    <item id="one" href="Text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="two" href="Text/chapter2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+  </manifest>
+  <spine><itemref idref="two"/><itemref idref="css"/><itemref idref="one"/></spine>
+</package>`
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+		err   bool
+	}{
+		{
+			name: "spine order",
+			files: map[string]string{
+				"META-INF/container.xml":     container,
+				"OEBPS/content.opf":          opf,
+				"OEBPS/Text/chapter 1.xhtml": `<html><body><p>Second&nbsp;part</p></body></html>`,
+				"OEBPS/Text/chapter2.xhtml":  `<html><head><style>p { color: red }</style></head><body><p>First<br>part</p><script>alert(1)</script></body></html>`,
+				"OEBPS/style.css":            `p { color: red }`,
+			},
+			want: []string{"First", "part", "Second", "part"},
+		},
+		{
+			name:  "no container",
+			files: map[string]string{"OEBPS/content.opf": opf},
+			err:   true,
+		},
+		{
+			name:  "missing chapter",
+			files: map[string]string{"META-INF/container.xml": container, "OEBPS/content.opf": opf},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, kind, err := Text("book.epub", zipFiles(t, tt.files))
+			if tt.err {
+				if err == nil {
+					t.Errorf("Text(book.epub) = %q, want an error", text)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Text(book.epub) error: %v", err)
+			}
+
+			if words := strings.Fields(text); kind != KindBook || strings.Join(words, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Text(book.epub) = %q %q, want %q %q", words, kind, tt.want, KindBook)
+			}
+		})
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	if _, _, err := Text("notes.txt", []byte("text")); err != ErrUnsupportedFormat {
+		t.Errorf("Text(notes.txt) error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		file, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := file.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,64 @@
+package extract
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	subtitleTag   = regexp.MustCompile(`<[^>]*>|\{[^}]*\}`)
+	vttBlockStart = []string{"WEBVTT", "NOTE", "STYLE", "REGION"}
+)
+
+// subtitlesText keeps the cue lines of .srt and .vtt files, dropping cue
+// numbers, timings, formatting tags and the WebVTT header and note blocks.
+func subtitlesText(data []byte) string {
+	content := strings.TrimPrefix(string(data), "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	lines := []string{}
+	skipBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			skipBlock = false
+			continue
+		}
+
+		if skipBlock || isVTTBlockStart(line) {
+			skipBlock = true
+			continue
+		}
+
+		if strings.Contains(line, "-->") || isNumber(line) {
+			continue
+		}
+
+		if text := strings.TrimSpace(subtitleTag.ReplaceAllString(line, "")); text != "" {
+			lines = append(lines, text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isVTTBlockStart(line string) bool {
+	for _, start := range vttBlockStart {
+		if line == start || strings.HasPrefix(line, start+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNumber(line string) bool {
+	for _, r := range line {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,187 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/mappers"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/repository"
+	"strconv"
+	"strings"
+)
+
+type correctionInteractor struct {
+	CorrectionRepository repository.CorrectionRepository
+	LibraryInteractor    LibraryInteractor
+}
+
+type CorrectionInteractor interface {
+	GetCorrectableWord(ctx context.Context, wordID string) (*models.Library, error)
+	SuggestCorrection(ctx context.Context, userID string, req *requests.CorrectionRequest) error
+	GetUserCorrections(ctx context.Context, userID string) ([]*models.Correction, error)
+	GetPendingCorrections(ctx context.Context) ([]*models.Correction, error)
+	ApproveCorrection(ctx context.Context, reviewerID string, req *requests.ResolveCorrectionRequest) error
+	RejectCorrection(ctx context.Context, reviewerID string, req *requests.ResolveCorrectionRequest) error
+}
+
+func NewCorrectionInteractor(c repository.CorrectionRepository, li LibraryInteractor) CorrectionInteractor {
+	return &correctionInteractor{CorrectionRepository: c, LibraryInteractor: li}
+}
+
+func (cs *correctionInteractor) GetCorrectableWord(ctx context.Context, wordID string) (*models.Library, error) {
+	id, err := strconv.Atoi(wordID)
+	if err != nil {
+		return nil, apperrors.SuggestCorrectionErr.AppendMessage("wrong word id", wordID)
+	}
+
+	return cs.LibraryInteractor.GetLibraryWord(ctx, id)
+}
+
+// SuggestCorrection files the user's new value of one field of a library
+// word. The current value is kept with it to check the word hasn't changed
+// by the time the correction is reviewed.
+func (cs *correctionInteractor) SuggestCorrection(ctx context.Context, userID string, req *requests.CorrectionRequest) error {
+	word, err := cs.GetCorrectableWord(ctx, req.WordID)
+	if err != nil {
+		return err
+	}
+
+	current := correctionField(mappers.MapLibraryToLibraryWordRequest(word), req.Field)
+	if current == nil {
+		return apperrors.SuggestCorrectionErr.AppendMessage("unknown field", req.Field)
+	}
+
+	value := strings.TrimSpace(req.Value)
+	if value == *current {
+		return apperrors.SuggestCorrectionErr.AppendMessage("the value hasn't changed")
+	}
+
+	corrections, err := cs.CorrectionRepository.GetCorrectionsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, correction := range corrections {
+		if correction.WordID == word.ID && correction.Field == req.Field && correction.Status == models.CorrectionPending {
+			return apperrors.SuggestCorrectionErr.AppendMessage("the correction has been suggested already")
+		}
+	}
+
+	correction := &models.Correction{
+		UserID:   userID,
+		WordID:   word.ID,
+		Word:     word.English + " — " + word.Russian,
+		Field:    req.Field,
+		OldValue: *current,
+		NewValue: value,
+		Comment:  strings.TrimSpace(req.Comment),
+		Status:   models.CorrectionPending,
+	}
+
+	return cs.CorrectionRepository.CreateCorrection(ctx, correction)
+}
+
+func (cs *correctionInteractor) GetUserCorrections(ctx context.Context, userID string) ([]*models.Correction, error) {
+	return cs.CorrectionRepository.GetCorrectionsByUserID(ctx, userID)
+}
+
+func (cs *correctionInteractor) GetPendingCorrections(ctx context.Context) ([]*models.Correction, error) {
+	return cs.CorrectionRepository.GetCorrectionsByStatus(ctx, models.CorrectionPending)
+}
+
+// ApproveCorrection writes the suggested value, or the value the admin edited
+// it to, to the library the way the library form does, so the new version of
+// the word is credited to the user who suggested it.
+func (cs *correctionInteractor) ApproveCorrection(ctx context.Context, reviewerID string, req *requests.ResolveCorrectionRequest) error {
+	correction, err := cs.pendingCorrection(ctx, req.CorrectionID)
+	if err != nil {
+		return err
+	}
+
+	if value := strings.TrimSpace(req.Value); value != "" {
+		correction.NewValue = value
+	}
+
+	word, err := cs.LibraryInteractor.GetLibraryWord(ctx, correction.WordID)
+	if err != nil {
+		return err
+	}
+
+	form := mappers.MapLibraryToLibraryWordRequest(word)
+	field := correctionField(form, correction.Field)
+	if field == nil {
+		return apperrors.ResolveCorrectionErr.AppendMessage("unknown field", correction.Field)
+	}
+
+	if *field != correction.OldValue {
+		return apperrors.StaleCorrectionErr.AppendMessage("the word has changed since the correction was suggested")
+	}
+
+	*field = correction.NewValue
+	if err := cs.LibraryInteractor.UpdateLibraryWord(ctx, correction.UserID, form); err != nil {
+		return err
+	}
+
+	correction.Status = models.CorrectionApproved
+	correction.Note = strings.TrimSpace(req.Note)
+	correction.ReviewerID = reviewerID
+	return cs.CorrectionRepository.UpdateCorrection(ctx, correction)
+}
+
+func (cs *correctionInteractor) RejectCorrection(ctx context.Context, reviewerID string, req *requests.ResolveCorrectionRequest) error {
+	correction, err := cs.pendingCorrection(ctx, req.CorrectionID)
+	if err != nil {
+		return err
+	}
+
+	correction.Status = models.CorrectionRejected
+	correction.Note = strings.TrimSpace(req.Note)
+	correction.ReviewerID = reviewerID
+	return cs.CorrectionRepository.UpdateCorrection(ctx, correction)
+}
+
+func (cs *correctionInteractor) pendingCorrection(ctx context.Context, correctionID string) (*models.Correction, error) {
+	id, err := strconv.Atoi(correctionID)
+	if err != nil {
+		return nil, apperrors.ResolveCorrectionErr.AppendMessage(err)
+	}
+
+	correction, err := cs.CorrectionRepository.GetCorrectionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if correction.Status != models.CorrectionPending {
+		return nil, apperrors.ResolveCorrectionErr.AppendMessage("the correction has been reviewed already")
+	}
+
+	return correction, nil
+}
+
+// correctionField points to the field of the library form users can correct,
+// nil for any other key.
+func correctionField(form *requests.LibraryWordRequest, key string) *string {
+	switch key {
+	case "english":
+		return &form.English
+	case "russian":
+		return &form.Russian
+	case "preposition":
+		return &form.Preposition
+	case "part_of_speech":
+		return &form.PartsOfSpeech
+	case "transcription":
+		return &form.Transcription
+	case "russian_stressed":
+		return &form.RussianStressed
+	case "forms":
+		return &form.Forms
+	case "register":
+		return &form.Register
+	case "usage_note":
+		return &form.UsageNote
+	}
+
+	return nil
+}
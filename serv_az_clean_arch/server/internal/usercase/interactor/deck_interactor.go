@@ -0,0 +1,335 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/extract"
+	"server/internal/usercase/repository"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deckInteractor struct {
+	DeckRepository    repository.DeckRepository
+	UserRepository    repository.UserRepository
+	LibraryInteractor LibraryInteractor
+}
+
+type DeckInteractor interface {
+	AnalyzeText(ctx context.Context, userID, text string, pair models.LanguagePair) (*models.TextAnalysis, error)
+	CreateDeckFromText(ctx context.Context, userID, name string, wordIDs []string) (*models.Deck, error)
+	ImportDeck(ctx context.Context, userID, name, filename string, data []byte, pair models.LanguagePair, learnLimit int) (*models.Deck, error)
+	CreateDeck(ctx context.Context, userID, name string) (*models.Deck, error)
+	GetUserDecks(ctx context.Context, userID string) ([]*models.Deck, error)
+	GetDeck(ctx context.Context, userID, deckID string) (*models.Deck, error)
+	DeleteDeck(ctx context.Context, userID, deckID string) error
+	AddCustomWord(ctx context.Context, userID string, req *requests.CustomWordRequest, pair models.LanguagePair) (*models.CustomWord, error)
+	DeleteCustomWord(ctx context.Context, userID, wordID string) error
+	GetDeckWords(ctx context.Context, userID, deckID string, pair models.LanguagePair, learn bool, limit int) (*models.Deck, []*models.Word, error)
+	CustomWordAnswered(ctx context.Context, userID string, id int, right bool) error
+	CustomWordLearned(ctx context.Context, userID string, id int) error
+}
+
+var deckSources = map[string]string{
+	extract.KindSubtitles: models.DeckSourceSubtitles,
+	extract.KindBook:      models.DeckSourceBook,
+}
+
+func NewDeckInteractor(d repository.DeckRepository, u repository.UserRepository, li LibraryInteractor) DeckInteractor {
+	return &deckInteractor{DeckRepository: d, UserRepository: u, LibraryInteractor: li}
+}
+
+// AnalyzeText leaves out the words the user has learned already.
+func (ds *deckInteractor) AnalyzeText(ctx context.Context, userID, text string, pair models.LanguagePair) (*models.TextAnalysis, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperrors.AnalyzeTextErr.AppendMessage(err)
+	}
+
+	analysis, err := ds.LibraryInteractor.AnalyzeText(ctx, text, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	learnedIDs, err := ds.UserRepository.GetLearnedWordIDs(ctx, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	learned := make(map[int]bool, len(learnedIDs))
+	for _, wordID := range learnedIDs {
+		learned[wordID] = true
+	}
+
+	unknown := []*models.AnalyzedWord{}
+	for _, word := range analysis.Words {
+		if word.LibraryID > 0 && learned[word.LibraryID] {
+			analysis.Learned++
+			continue
+		}
+
+		unknown = append(unknown, word)
+	}
+
+	analysis.Words = unknown
+	return analysis, nil
+}
+
+// CreateDeckFromText saves the chosen words as a personal deck and puts them
+// into the user's learn queue.
+func (ds *deckInteractor) CreateDeckFromText(ctx context.Context, userID, name string, wordIDs []string) (*models.Deck, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, apperrors.CreateDeckFromTextErr.AppendMessage(err)
+	}
+
+	ids := []int{}
+	seen := make(map[int]bool)
+	for _, wordID := range wordIDs {
+		wordIDInt, err := strconv.Atoi(wordID)
+		if err != nil || wordIDInt <= 0 {
+			return nil, apperrors.CreateDeckFromTextErr.AppendMessage("wrong word id", wordID)
+		}
+
+		if !seen[wordIDInt] {
+			seen[wordIDInt] = true
+			ids = append(ids, wordIDInt)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, apperrors.CreateDeckFromTextErr.AppendMessage("no words chosen")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Текст от %s", time.Now().Format("02.01.2006 15:04"))
+	}
+
+	deck := &models.Deck{UserID: userID, Name: name, Source: models.DeckSourceText}
+	if err := ds.DeckRepository.CreateDeck(ctx, deck, ids, ids); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ImportDeck extracts the text of subtitles or a book and saves the library
+// words of it the user doesn't know as a deck, putting the learnLimit most
+// frequent of them into the learn queue. The deck is named after the file
+// unless a name is given.
+func (ds *deckInteractor) ImportDeck(ctx context.Context, userID, name, filename string, data []byte, pair models.LanguagePair, learnLimit int) (*models.Deck, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, apperrors.ImportDeckErr.AppendMessage(err)
+	}
+
+	text, kind, err := extract.Text(filename, data)
+	if err != nil {
+		return nil, apperrors.ImportDeckErr.AppendMessage(filename, err)
+	}
+
+	analysis, err := ds.AnalyzeText(ctx, userID, text, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	seen := make(map[int]bool)
+	for _, word := range analysis.Words {
+		if word.LibraryID > 0 && !seen[word.LibraryID] {
+			seen[word.LibraryID] = true
+			ids = append(ids, word.LibraryID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, apperrors.ImportDeckErr.AppendMessage("no unknown library words in", filename)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	}
+
+	learnIDs := ids
+	if len(learnIDs) > learnLimit {
+		learnIDs = learnIDs[:learnLimit]
+	}
+
+	deck := &models.Deck{UserID: userID, Name: name, Source: deckSources[kind]}
+	if err := ds.DeckRepository.CreateDeck(ctx, deck, ids, learnIDs); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// CreateDeck creates an empty private deck for the user's own words.
+func (ds *deckInteractor) CreateDeck(ctx context.Context, userID, name string) (*models.Deck, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperrors.DeckErr.AppendMessage("the deck name is empty")
+	}
+
+	deck := &models.Deck{UserID: userID, Name: name, Source: models.DeckSourceCustom}
+	if err := ds.DeckRepository.CreateDeck(ctx, deck, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+func (ds *deckInteractor) GetUserDecks(ctx context.Context, userID string) ([]*models.Deck, error) {
+	return ds.DeckRepository.GetDecksByUserID(ctx, userID)
+}
+
+// GetDeck returns the user's deck with its library and custom words.
+func (ds *deckInteractor) GetDeck(ctx context.Context, userID, deckID string) (*models.Deck, error) {
+	deck, err := ds.userDeck(ctx, userID, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ds.DeckRepository.LoadDeckWords(ctx, deck); err != nil {
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+func (ds *deckInteractor) DeleteDeck(ctx context.Context, userID, deckID string) error {
+	deck, err := ds.userDeck(ctx, userID, deckID)
+	if err != nil {
+		return err
+	}
+
+	return ds.DeckRepository.DeleteDeck(ctx, deck)
+}
+
+// AddCustomWord adds the user's own word in the target language of the pair
+// with a translation in the source one. When asked, the word is linked to the
+// library entry it or its translation matches.
+func (ds *deckInteractor) AddCustomWord(ctx context.Context, userID string, req *requests.CustomWordRequest, pair models.LanguagePair) (*models.CustomWord, error) {
+	deck, err := ds.userDeck(ctx, userID, req.DeckID)
+	if err != nil {
+		return nil, err
+	}
+
+	word := &models.CustomWord{
+		DeckID:              deck.ID,
+		UserID:              userID,
+		Text:                strings.TrimSpace(req.Text),
+		Translation:         strings.TrimSpace(req.Translation),
+		Language:            pair.Target,
+		TranslationLanguage: pair.Source,
+		Learn:               true,
+	}
+
+	if word.Text == "" || word.Translation == "" {
+		return nil, apperrors.CustomWordErr.AppendMessage("the word and the translation are required")
+	}
+
+	if req.Link {
+		lemma := ds.LibraryInteractor.MatchLemma(word.Language, word.Text)
+		if lemma == nil {
+			lemma = ds.LibraryInteractor.MatchLemma(word.TranslationLanguage, word.Translation)
+		}
+
+		if lemma == nil {
+			return nil, apperrors.CustomWordErr.AppendMessage("there is no library entry for", word.Text)
+		}
+
+		word.LibraryID = lemma.LibraryID
+	}
+
+	if err := ds.DeckRepository.AddCustomWord(ctx, word); err != nil {
+		return nil, err
+	}
+
+	return word, nil
+}
+
+func (ds *deckInteractor) DeleteCustomWord(ctx context.Context, userID, wordID string) error {
+	id, err := strconv.Atoi(wordID)
+	if err != nil {
+		return apperrors.CustomWordErr.AppendMessage("wrong word id", wordID)
+	}
+
+	return ds.DeckRepository.DeleteCustomWord(ctx, id, userID)
+}
+
+// GetDeckWords returns the deck words to learn or, when learn isn't set, to
+// test on: the user's own words in the pair first, then the library ones.
+func (ds *deckInteractor) GetDeckWords(ctx context.Context, userID, deckID string, pair models.LanguagePair, learn bool, limit int) (*models.Deck, []*models.Word, error) {
+	deck, err := ds.userDeck(ctx, userID, deckID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	customWords, err := ds.DeckRepository.GetCustomWords(ctx, deck, learn, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	words := []*models.Word{}
+	for _, customWord := range customWords {
+		if word := studyWord(customWord, pair); word != nil {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) >= limit {
+		return deck, words, nil
+	}
+
+	libraryWords, err := ds.DeckRepository.GetDeckWords(ctx, deck, learn, limit-len(words))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deck, append(words, libraryWords...), nil
+}
+
+// CustomWordAnswered keeps the test result of a custom word: a right answer
+// makes it learned, a wrong one puts it into the learn queue.
+func (ds *deckInteractor) CustomWordAnswered(ctx context.Context, userID string, id int, right bool) error {
+	return ds.DeckRepository.UpdateCustomWordProgress(ctx, id, userID, !right, right)
+}
+
+// CustomWordLearned takes a custom word out of the learn queue.
+func (ds *deckInteractor) CustomWordLearned(ctx context.Context, userID string, id int) error {
+	return ds.DeckRepository.UpdateCustomWordProgress(ctx, id, userID, false, false)
+}
+
+func (ds *deckInteractor) userDeck(ctx context.Context, userID, deckID string) (*models.Deck, error) {
+	id, err := strconv.Atoi(deckID)
+	if err != nil {
+		return nil, apperrors.DeckErr.AppendMessage("wrong deck id", deckID)
+	}
+
+	return ds.DeckRepository.GetDeck(ctx, id, userID)
+}
+
+// studyWord turns a custom word into a test word of the pair, the prompt in
+// the source language and the answer in the target one. Words of other
+// languages give nil. ID is the linked library entry, so its other
+// translations are accepted as well.
+func studyWord(word *models.CustomWord, pair models.LanguagePair) *models.Word {
+	item := &models.Word{ID: word.LibraryID, CustomID: word.ID, Image: word.Image}
+	switch {
+	case word.Language == pair.Target && word.TranslationLanguage == pair.Source:
+		item.English, item.Russian = word.Text, word.Translation
+	case word.Language == pair.Source && word.TranslationLanguage == pair.Target:
+		item.English, item.Russian = word.Translation, word.Text
+	default:
+		return nil
+	}
+
+	return item
+}
@@ -0,0 +1,147 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/repository"
+	"strconv"
+	"strings"
+)
+
+type disputeInteractor struct {
+	DisputeRepository repository.DisputeRepository
+	LibraryInteractor LibraryInteractor
+	UserInteractor    UserInteractor
+}
+
+type DisputeInteractor interface {
+	FileDispute(ctx context.Context, userID string, word *models.Word, language string) error
+	GetUserDisputes(ctx context.Context, userID string) ([]*models.Dispute, error)
+	GetPendingDisputes(ctx context.Context) ([]*models.Dispute, error)
+	AcceptDispute(ctx context.Context, req *requests.ResolveDisputeRequest) (*models.Dispute, error)
+	RejectDispute(ctx context.Context, req *requests.ResolveDisputeRequest) error
+}
+
+func NewDisputeInteractor(d repository.DisputeRepository, li LibraryInteractor, ui UserInteractor) DisputeInteractor {
+	return &disputeInteractor{DisputeRepository: d, LibraryInteractor: li, UserInteractor: ui}
+}
+
+func (ds *disputeInteractor) FileDispute(ctx context.Context, userID string, word *models.Word, language string) error {
+	if word.Right {
+		return apperrors.FileDisputeErr.AppendMessage("the answer has been accepted already")
+	}
+
+	if word.CustomID > 0 {
+		return apperrors.FileDisputeErr.AppendMessage("the user's own words aren't checked by admins")
+	}
+
+	if strings.TrimSpace(word.Answer) == "" {
+		return apperrors.FileDisputeErr.AppendMessage("the answer is empty")
+	}
+
+	disputes, err := ds.DisputeRepository.GetDisputesByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, dispute := range disputes {
+		if dispute.WordID == word.ID && dispute.Status == models.DisputePending {
+			return apperrors.FileDisputeErr.AppendMessage("the dispute has been filed already")
+		}
+	}
+
+	expected := word.English
+	if word.ClozeAnswer != "" {
+		expected = word.ClozeAnswer
+	}
+
+	dispute := &models.Dispute{
+		UserID:   userID,
+		WordID:   word.ID,
+		Prompt:   word.Russian,
+		Expected: expected,
+		Given:    strings.TrimSpace(word.Answer),
+		Language: language,
+		Status:   models.DisputePending,
+	}
+
+	return ds.DisputeRepository.CreateDispute(ctx, dispute)
+}
+
+func (ds *disputeInteractor) GetUserDisputes(ctx context.Context, userID string) ([]*models.Dispute, error) {
+	return ds.DisputeRepository.GetDisputesByUserID(ctx, userID)
+}
+
+func (ds *disputeInteractor) GetPendingDisputes(ctx context.Context) ([]*models.Dispute, error) {
+	return ds.DisputeRepository.GetDisputesByStatus(ctx, models.DisputePending)
+}
+
+// AcceptDispute adds the given answer as a synonym of the word, English ones
+// to its translation group, Russian ones as a linked lemma and the others as
+// lemmas of the word, and moves the
+// word to the user's learned words out of the learn queue, as if the comparer
+// had accepted the answer. It returns the accepted dispute.
+func (ds *disputeInteractor) AcceptDispute(ctx context.Context, req *requests.ResolveDisputeRequest) (*models.Dispute, error) {
+	dispute, err := ds.pendingDispute(ctx, req.DisputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch dispute.Language {
+	case "", models.LangEnglish:
+		err = ds.LibraryInteractor.AddAnswerToWordGroup(ctx, dispute.WordID, dispute.Given)
+	case models.LangRussian:
+		err = ds.LibraryInteractor.AddRussianAnswer(ctx, dispute.WordID, dispute.Given)
+	default:
+		lemmaReq := &requests.LemmaRequest{WordID: strconv.Itoa(dispute.WordID), Language: dispute.Language, Text: dispute.Given}
+		err = ds.LibraryInteractor.AddLemma(ctx, lemmaReq)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	wordID := strconv.Itoa(dispute.WordID)
+	if err := ds.UserInteractor.MoveWordToLearned(ctx, dispute.UserID, wordID); err != nil {
+		return nil, err
+	}
+
+	if err := ds.UserInteractor.DeleteLearnFromUserById(ctx, dispute.UserID, wordID); err != nil {
+		return nil, err
+	}
+
+	if err := ds.DisputeRepository.UpdateDisputeStatus(ctx, dispute.ID, models.DisputeAccepted, strings.TrimSpace(req.Note)); err != nil {
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+func (ds *disputeInteractor) RejectDispute(ctx context.Context, req *requests.ResolveDisputeRequest) error {
+	dispute, err := ds.pendingDispute(ctx, req.DisputeID)
+	if err != nil {
+		return err
+	}
+
+	return ds.DisputeRepository.UpdateDisputeStatus(ctx, dispute.ID, models.DisputeRejected, strings.TrimSpace(req.Note))
+}
+
+func (ds *disputeInteractor) pendingDispute(ctx context.Context, disputeID string) (*models.Dispute, error) {
+	id, err := strconv.Atoi(disputeID)
+	if err != nil {
+		return nil, apperrors.ResolveDisputeErr.AppendMessage(err)
+	}
+
+	dispute, err := ds.DisputeRepository.GetDisputeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dispute.Status != models.DisputePending {
+		return nil, apperrors.ResolveDisputeErr.AppendMessage("the dispute has been resolved already")
+	}
+
+	return dispute, nil
+}
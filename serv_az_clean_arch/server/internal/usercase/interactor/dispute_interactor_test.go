@@ -0,0 +1,168 @@
+package interactor
+
+import (
+	"context"
+	"reflect"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/repository"
+	"strconv"
+	"testing"
+)
+
+// The fakes embed the interfaces they stand for, methods the tests don't
+// reach panic.
+
+type fakeDisputeRepository struct {
+	repository.DisputeRepository
+	dispute *models.Dispute
+}
+
+func (f *fakeDisputeRepository) GetDisputeByID(ctx context.Context, id int) (*models.Dispute, error) {
+	dispute := *f.dispute
+	return &dispute, nil
+}
+
+func (f *fakeDisputeRepository) UpdateDisputeStatus(ctx context.Context, id int, status, note string) error {
+	f.dispute.Status = status
+	return nil
+}
+
+type fakeLibraryRepository struct {
+	repository.LibraryRepository
+	word   *models.Library
+	seeds  []*models.LibrarySeed
+	images map[int]string
+}
+
+func (f *fakeLibraryRepository) GetWordByID(ctx context.Context, id int) (*models.Library, error) {
+	word := *f.word
+	return &word, nil
+}
+
+func (f *fakeLibraryRepository) UpdateWordsMap() error {
+	return nil
+}
+
+type fakeGroupRepository struct {
+	repository.TranslationGroupRepository
+	groups []*models.TranslationGroup
+}
+
+func (f *fakeGroupRepository) GetAllGroups(ctx context.Context) ([]*models.TranslationGroup, error) {
+	return f.groups, nil
+}
+
+func (f *fakeGroupRepository) GetGroupByID(ctx context.Context, id int) (*models.TranslationGroup, error) {
+	return f.groups[id-1], nil
+}
+
+func (f *fakeGroupRepository) CreateGroup(ctx context.Context, group *models.TranslationGroup) error {
+	group.ID = len(f.groups) + 1
+	f.groups = append(f.groups, group)
+	return nil
+}
+
+func (f *fakeGroupRepository) SetWordGroup(ctx context.Context, wordID, groupID int) error {
+	return nil
+}
+
+func (f *fakeGroupRepository) AddVariant(ctx context.Context, variant *models.TranslationVariant) error {
+	group := f.groups[variant.TranslationGroupID-1]
+	group.Variants = append(group.Variants, variant)
+	return nil
+}
+
+type fakeLemmaRepository struct {
+	repository.LemmaRepository
+	concept map[string][]string
+	added   []string
+}
+
+func (f *fakeLemmaRepository) ConceptLemmas(libraryID int) map[string][]string {
+	return f.concept
+}
+
+func (f *fakeLemmaRepository) AddLemma(ctx context.Context, lemma *models.Lemma) error {
+	f.added = append(f.added, lemma.Language+":"+lemma.Text+":"+strconv.Itoa(lemma.LibraryID))
+	return nil
+}
+
+func (f *fakeLemmaRepository) AddLinkedLemma(ctx context.Context, libraryID int, lemma *models.Lemma) error {
+	f.added = append(f.added, lemma.Language+":"+lemma.Text+":linked to "+strconv.Itoa(libraryID))
+	return nil
+}
+
+type fakeUserInteractor struct {
+	UserInteractor
+	learned  []string
+	unqueued []string
+}
+
+func (f *fakeUserInteractor) MoveWordToLearned(ctx context.Context, userID, wordID string) error {
+	f.learned = append(f.learned, userID+":"+wordID)
+	return nil
+}
+
+func (f *fakeUserInteractor) DeleteLearnFromUserById(ctx context.Context, userID, wordID string) error {
+	f.unqueued = append(f.unqueued, userID+":"+wordID)
+	return nil
+}
+
+func TestAcceptDispute(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		given    string
+		variants []string
+		lemmas   []string
+	}{
+		{name: "default pair", language: models.LangEnglish, given: " kitty ", variants: []string{"Kitty"}, lemmas: []string{}},
+		{name: "default pair, language not recorded", language: "", given: "kitty", variants: []string{"Kitty"}, lemmas: []string{}},
+		{name: "default pair, known answer", language: models.LangEnglish, given: "cat", variants: []string{}, lemmas: []string{}},
+		{name: "russian target", language: models.LangRussian, given: "котик", variants: []string{}, lemmas: []string{"ru:Котик:linked to 7"}},
+		{name: "russian target, known answer", language: models.LangRussian, given: "кот", variants: []string{}, lemmas: []string{}},
+		{name: "other language", language: "de", given: "katze", variants: []string{}, lemmas: []string{"de:Katze:7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disputes := &fakeDisputeRepository{dispute: &models.Dispute{
+				ID: 3, UserID: "user", WordID: 7, Given: tt.given, Language: tt.language, Status: models.DisputePending,
+			}}
+			groups := &fakeGroupRepository{}
+			lemmas := &fakeLemmaRepository{concept: map[string][]string{models.LangRussian: {"Кот"}}}
+			users := &fakeUserInteractor{}
+			library := &libraryInteractor{
+				LibraryRepository: &fakeLibraryRepository{word: &models.Library{ID: 7, English: "Cat", Russian: "Кот"}},
+				GroupRepository:   groups,
+				LemmaRepository:   lemmas,
+			}
+
+			ds := NewDisputeInteractor(disputes, library, users)
+			if _, err := ds.AcceptDispute(context.Background(), &requests.ResolveDisputeRequest{DisputeID: "3"}); err != nil {
+				t.Fatalf("AcceptDispute() error = %v", err)
+			}
+
+			variants := []string{}
+			for _, group := range groups.groups {
+				for _, variant := range group.Variants {
+					variants = append(variants, variant.English)
+				}
+			}
+
+			added := append([]string{}, lemmas.added...)
+			if !reflect.DeepEqual(variants, tt.variants) || !reflect.DeepEqual(added, tt.lemmas) {
+				t.Errorf("AcceptDispute() variants = %q, lemmas = %q, want %q, %q", variants, added, tt.variants, tt.lemmas)
+			}
+
+			if disputes.dispute.Status != models.DisputeAccepted {
+				t.Errorf("AcceptDispute() status = %q, want %q", disputes.dispute.Status, models.DisputeAccepted)
+			}
+
+			if want := []string{"user:7"}; !reflect.DeepEqual(users.learned, want) || !reflect.DeepEqual(users.unqueued, want) {
+				t.Errorf("AcceptDispute() learned = %q, unqueued = %q, want %q", users.learned, users.unqueued, want)
+			}
+		})
+	}
+}
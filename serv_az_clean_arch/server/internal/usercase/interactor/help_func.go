@@ -58,13 +58,3 @@ func capitalizeFirstRune(line string) string {
 
 	return string(runes)
 }
-
-func isCyrillic(s string) bool {
-	for _, r := range s {
-		if unicode.Is(unicode.Cyrillic, r) {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,65 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+	"strings"
+)
+
+const historyLimit = 200
+
+type historyInteractor struct {
+	HistoryRepository repository.HistoryRepository
+	UserRepository    repository.UserRepository
+}
+
+type HistoryInteractor interface {
+	SetKeepHistory(ctx context.Context, userID string, keep bool) error
+	RecordLookup(ctx context.Context, userID, text string, pair models.LanguagePair, lookup *models.TranslationLookup) error
+	GetHistory(ctx context.Context, userID string) ([]*models.LookupHistory, error)
+	ClearHistory(ctx context.Context, userID string) error
+}
+
+func NewHistoryInteractor(h repository.HistoryRepository, u repository.UserRepository) HistoryInteractor {
+	return &historyInteractor{HistoryRepository: h, UserRepository: u}
+}
+
+// SetKeepHistory turns the lookup history on or off, turning it off deletes
+// what was kept.
+func (hs *historyInteractor) SetKeepHistory(ctx context.Context, userID string, keep bool) error {
+	if err := hs.UserRepository.UpdateUserKeepHistory(ctx, userID, keep); err != nil {
+		return err
+	}
+
+	if keep {
+		return nil
+	}
+
+	return hs.HistoryRepository.DeleteUserHistory(ctx, userID)
+}
+
+// RecordLookup keeps a lookup of a user who turned the history on.
+func (hs *historyInteractor) RecordLookup(ctx context.Context, userID, text string, pair models.LanguagePair, lookup *models.TranslationLookup) error {
+	entry := &models.LookupHistory{
+		UserID: userID,
+		Text:   strings.TrimSpace(text),
+		Source: pair.Source,
+		Target: pair.Target,
+	}
+
+	if lookup != nil && len(lookup.Translations) > 0 {
+		entry.Found = true
+		entry.LibraryID = lookup.Translations[0].From.LibraryID
+	}
+
+	return hs.HistoryRepository.AddLookup(ctx, entry)
+}
+
+func (hs *historyInteractor) GetHistory(ctx context.Context, userID string) ([]*models.LookupHistory, error) {
+	return hs.HistoryRepository.GetUserHistory(ctx, userID, historyLimit)
+}
+
+func (hs *historyInteractor) ClearHistory(ctx context.Context, userID string) error {
+	return hs.HistoryRepository.DeleteUserHistory(ctx, userID)
+}
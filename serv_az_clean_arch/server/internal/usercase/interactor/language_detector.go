@@ -0,0 +1,191 @@
+package interactor
+
+import (
+	"fmt"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// latinHomoglyphs holds the letters that look the same in both scripts.
+// Upper case only pairs are kept apart since their lower cases differ.
+var (
+	latinHomoglyphs = map[rune]rune{
+		'a': 'а', 'c': 'с', 'e': 'е', 'i': 'і', 'k': 'к', 'o': 'о', 'p': 'р', 'x': 'х', 'y': 'у',
+	}
+	latinHomoglyphsUpper = map[rune]rune{
+		'B': 'В', 'H': 'Н', 'M': 'М', 'T': 'Т',
+	}
+	cyrillicHomoglyphs      = invertRunes(latinHomoglyphs)
+	cyrillicHomoglyphsUpper = invertRunes(latinHomoglyphsUpper)
+)
+
+// detectLanguages returns the text with homoglyphs of the minority script
+// replaced and the configured languages whose alphabet covers every letter,
+// languages with letters no other one has first. No languages means the text
+// can't be any of them.
+func detectLanguages(text string) (string, []string) {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r):
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			return text, nil
+		}
+	}
+
+	variants := []string{text}
+	if cyrillic > 0 && latin > 0 {
+		toCyrillic, okCyrillic := replaceHomoglyphs(text, unicode.Latin, latinHomoglyphs, latinHomoglyphsUpper)
+		toLatin, okLatin := replaceHomoglyphs(text, unicode.Cyrillic, cyrillicHomoglyphs, cyrillicHomoglyphsUpper)
+		variants = variants[:0]
+		if cyrillic >= latin {
+			variants = appendIf(variants, toCyrillic, okCyrillic)
+			variants = appendIf(variants, toLatin, okLatin)
+		} else {
+			variants = appendIf(variants, toLatin, okLatin)
+			variants = appendIf(variants, toCyrillic, okCyrillic)
+		}
+	}
+
+	for _, variant := range variants {
+		if languages := scoreLanguages(variant); len(languages) > 0 {
+			return variant, languages
+		}
+	}
+
+	return text, nil
+}
+
+func scoreLanguages(text string) []string {
+	letters := []rune{}
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+
+	if len(letters) == 0 {
+		return nil
+	}
+
+	type score struct {
+		code   string
+		unique int
+	}
+
+	scores := []score{}
+	for _, language := range models.Languages {
+		covered, unique := true, 0
+		for _, r := range letters {
+			if !strings.ContainsRune(language.Alphabet, r) {
+				covered = false
+				break
+			}
+
+			if onlyIn(language, r) {
+				unique++
+			}
+		}
+
+		if covered {
+			scores = append(scores, score{code: language.Code, unique: unique})
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].unique > scores[j].unique
+	})
+
+	languages := make([]string, 0, len(scores))
+	for _, s := range scores {
+		languages = append(languages, s.code)
+	}
+
+	return languages
+}
+
+// pairDirections turns the detected languages into lookup directions within
+// the pair, the most likely first.
+func pairDirections(detected []string, pair models.LanguagePair) []models.LanguagePair {
+	directions := []models.LanguagePair{}
+	for _, code := range detected {
+		switch code {
+		case pair.Source:
+			directions = append(directions, pair)
+		case pair.Target:
+			directions = append(directions, models.LanguagePair{Source: pair.Target, Target: pair.Source})
+		}
+	}
+
+	return directions
+}
+
+func unsupportedLanguage(text string, detected []string, pair models.LanguagePair) error {
+	if len(detected) == 0 {
+		return apperrors.UnsupportedLanguageErr.AppendMessage(fmt.Sprintf("%q is in none of the supported languages", text))
+	}
+
+	return apperrors.UnsupportedLanguageErr.AppendMessage(fmt.Sprintf("%q looks like %s, the pair is %s-%s",
+		text, models.GetLanguage(detected[0]).Name, pair.Source, pair.Target))
+}
+
+func onlyIn(language *models.Language, r rune) bool {
+	for _, other := range models.Languages {
+		if other != language && strings.ContainsRune(other.Alphabet, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func replaceHomoglyphs(text string, script *unicode.RangeTable, lower, upper map[rune]rune) (string, bool) {
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.Is(script, r) {
+			continue
+		}
+
+		if replacement, ok := upper[r]; ok {
+			runes[i] = replacement
+			continue
+		}
+
+		replacement, ok := lower[unicode.ToLower(r)]
+		if !ok {
+			return text, false
+		}
+
+		if unicode.IsUpper(r) {
+			replacement = unicode.ToUpper(replacement)
+		}
+
+		runes[i] = replacement
+	}
+
+	return string(runes), true
+}
+
+func invertRunes(runes map[rune]rune) map[rune]rune {
+	inverted := make(map[rune]rune, len(runes))
+	for from, to := range runes {
+		inverted[to] = from
+	}
+
+	return inverted
+}
+
+func appendIf(variants []string, variant string, ok bool) []string {
+	if ok {
+		return append(variants, variant)
+	}
+
+	return variants
+}
@@ -0,0 +1,61 @@
+package interactor
+
+import (
+	"reflect"
+	"server/internal/domain/models"
+	"testing"
+)
+
+func TestDetectLanguages(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		want      string
+		languages []string
+	}{
+		{name: "latin", text: "cat", want: "cat", languages: []string{"en", "de"}},
+		{name: "german letters", text: "Straße", want: "Straße", languages: []string{"de"}},
+		{name: "cyrillic", text: "кот", want: "кот", languages: []string{"ru", "uk"}},
+		{name: "russian letters", text: "ёж", want: "ёж", languages: []string{"ru"}},
+		{name: "ukrainian letters", text: "їжак", want: "їжак", languages: []string{"uk"}},
+		{name: "latin o in cyrillic word", text: "кoт", want: "кот", languages: []string{"ru", "uk"}},
+		{name: "cyrillic c in latin word", text: "сat", want: "cat", languages: []string{"en", "de"}},
+		{name: "upper case only homoglyph", text: "Bот", want: "Вот", languages: []string{"ru", "uk"}},
+		{name: "mixed without homoglyphs", text: "кtл", want: "кtл", languages: nil},
+		{name: "other script", text: "日本", want: "日本", languages: nil},
+		{name: "no letters", text: "123", want: "123", languages: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, languages := detectLanguages(tt.text)
+			if text != tt.want || !reflect.DeepEqual(languages, tt.languages) {
+				t.Errorf("detectLanguages(%q) = %q %v, want %q %v", tt.text, text, languages, tt.want, tt.languages)
+			}
+		})
+	}
+}
+
+func TestPairDirections(t *testing.T) {
+	pair := models.LanguagePair{Source: "ru", Target: "en"}
+	reversed := models.LanguagePair{Source: "en", Target: "ru"}
+
+	tests := []struct {
+		name       string
+		detected   []string
+		directions []models.LanguagePair
+	}{
+		{name: "source", detected: []string{"ru", "uk"}, directions: []models.LanguagePair{pair}},
+		{name: "target", detected: []string{"en", "de"}, directions: []models.LanguagePair{reversed}},
+		{name: "both in order", detected: []string{"en", "ru"}, directions: []models.LanguagePair{reversed, pair}},
+		{name: "outside the pair", detected: []string{"uk"}, directions: []models.LanguagePair{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if directions := pairDirections(tt.detected, pair); !reflect.DeepEqual(directions, tt.directions) {
+				t.Errorf("pairDirections(%v) = %v, want %v", tt.detected, directions, tt.directions)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/usercase/morphology"
+	"server/internal/usercase/search"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// AnalyzeText counts the words of the text by library entry, inflected forms
+// of a word counted together, and lists the words missing from the library
+// by their own form. The most frequent words go first. Texts often quote
+// words of another language, so the language most words are in is analyzed
+// and the rest skipped.
+func (ls *libraryInteractor) AnalyzeText(ctx context.Context, text string, pair models.LanguagePair) (*models.TextAnalysis, error) {
+	if err := validateLanguagePair(pair); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, apperrors.AnalyzeTextErr.AppendMessage("the text is empty")
+	}
+
+	formsBySource := make(map[string]map[string]int)
+	votes := make(map[models.LanguagePair]int)
+	var direction models.LanguagePair
+	for _, token := range morphology.Tokenize(text) {
+		if !hasLetter(token) {
+			continue
+		}
+
+		repaired, detected := detectLanguages(token)
+		directions := pairDirections(detected, pair)
+		if len(directions) == 0 {
+			continue
+		}
+
+		votes[directions[0]]++
+		if votes[directions[0]] > votes[direction] {
+			direction = directions[0]
+		}
+
+		if formsBySource[directions[0].Source] == nil {
+			formsBySource[directions[0].Source] = make(map[string]int)
+		}
+
+		formsBySource[directions[0].Source][search.Normalize(repaired)]++
+	}
+
+	if votes[direction] == 0 {
+		repaired, detected := detectLanguages(shortText(text))
+		return nil, unsupportedLanguage(repaired, detected, pair)
+	}
+
+	forms := formsBySource[direction.Source]
+	analysis := &models.TextAnalysis{Pair: direction, Tokens: votes[direction]}
+
+	byKey := make(map[string]*models.AnalyzedWord)
+	for form, count := range forms {
+		lemmas := ls.LemmaRepository.SearchLemmas(direction.Source, form, false, 1)
+		if len(lemmas) == 0 {
+			lemmas, _ = ls.morphLemmas(direction.Source, form)
+		}
+
+		key := form
+		word := &models.AnalyzedWord{Text: form}
+		if len(lemmas) > 0 {
+			lemma := lemmas[0]
+			key = lemma.Language + ":" + lemma.Text
+			word = &models.AnalyzedWord{Text: form, Lemma: lemma.Text, LibraryID: lemma.LibraryID}
+			if translations := ls.LemmaRepository.ConceptLemmas(lemma.LibraryID)[direction.Target]; len(translations) > 0 {
+				word.Translation = translations[0]
+			}
+		}
+
+		if existing, ok := byKey[key]; ok {
+			word = existing
+		} else {
+			byKey[key] = word
+		}
+
+		word.Count += count
+	}
+
+	for _, word := range byKey {
+		analysis.Words = append(analysis.Words, word)
+	}
+
+	sort.Slice(analysis.Words, func(i, j int) bool {
+		if analysis.Words[i].Count != analysis.Words[j].Count {
+			return analysis.Words[i].Count > analysis.Words[j].Count
+		}
+
+		return analysis.Words[i].Text < analysis.Words[j].Text
+	})
+
+	return analysis, nil
+}
+
+func hasLetter(token string) bool {
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shortText keeps error messages about long texts readable.
+func shortText(text string) string {
+	const maxRunes = 40
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxRunes {
+		return string(runes)
+	}
+
+	return string(runes[:maxRunes]) + "…"
+}
@@ -0,0 +1,192 @@
+package interactor
+
+import (
+	"context"
+	"math/rand"
+	"regexp"
+	"server/internal/apperrors"
+	"server/internal/domain/mappers"
+	"server/internal/domain/models"
+	"strings"
+	"unicode"
+
+	"github.com/tealeg/xlsx"
+)
+
+const clozeGap = "_____"
+
+// BuildClozeTest picks up to limit words that have an example sentence and
+// blanks the word out of it. ClozeAnswer of the returned copies is the form
+// found in the sentence, so the comparer checks exactly what was blanked.
+func (ls *libraryInteractor) BuildClozeTest(ctx context.Context, words []*models.Word, limit int) ([]*models.Word, error) {
+	ids := make([]int, 0, len(words))
+	for _, word := range words {
+		ids = append(ids, word.ID)
+	}
+
+	phrases, err := ls.PhraseRepository.GetPhrasesByWordIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	phrasesByWord := make(map[int][]*models.Phrase)
+	for _, phrase := range phrases {
+		for _, library := range phrase.Libraries {
+			phrasesByWord[library.ID] = append(phrasesByWord[library.ID], phrase)
+		}
+	}
+
+	cloze := []*models.Word{}
+	for _, word := range words {
+		if len(cloze) >= limit {
+			break
+		}
+
+		candidates := phrasesByWord[word.ID]
+		rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+		for _, phrase := range candidates {
+			sentence, answer, ok := blankOut(phrase.English, word)
+			if !ok {
+				continue
+			}
+
+			item := *word
+			item.ClozeAnswer = answer
+			item.Cloze = sentence
+			item.ClozeTranslation = phrase.Russian
+			cloze = append(cloze, &item)
+			break
+		}
+	}
+
+	return cloze, nil
+}
+
+// blankOut replaces the first whole-word occurrence of the word or one of its
+// forms in the sentence with a gap.
+func blankOut(sentence string, word *models.Word) (string, string, bool) {
+	for _, form := range wordForms(word) {
+		pattern, err := regexp.Compile(`(?i)\b` + regexp.QuoteMeta(form) + `\b`)
+		if err != nil {
+			continue
+		}
+
+		loc := pattern.FindStringIndex(sentence)
+		if loc == nil {
+			continue
+		}
+
+		return sentence[:loc[0]] + clozeGap + sentence[loc[1]:], sentence[loc[0]:loc[1]], true
+	}
+
+	return "", "", false
+}
+
+func wordForms(word *models.Word) []string {
+	forms := []string{strings.TrimSpace(word.English)}
+	for _, form := range strings.FieldsFunc(word.Forms, func(r rune) bool { return r == ',' || r == '/' || r == ';' }) {
+		if form = strings.TrimSpace(form); form != "" {
+			forms = append(forms, form)
+		}
+	}
+
+	return forms
+}
+
+// importPhrases upserts the sentences sheet. A phrase without word IDs is
+// linked to every library word it contains.
+func (ls *libraryInteractor) importPhrases(ctx context.Context, fileXLS *xlsx.File) error {
+	phrases, err := mappers.MapXLStoPhrases(fileXLS)
+	if err != nil {
+		return err
+	}
+
+	if len(phrases) == 0 {
+		return nil
+	}
+
+	library, err := ls.LibraryRepository.GetAllWords()
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int]bool, len(library))
+	for _, libWord := range library {
+		known[libWord.ID] = true
+	}
+
+	index := newPhraseIndex(library)
+
+	for _, phrase := range phrases {
+		for _, libWord := range phrase.Libraries {
+			if !known[libWord.ID] {
+				return apperrors.ImportPhrasesErr.AppendMessage("unknown word id", libWord.ID, "in sentence with id", phrase.ID)
+			}
+		}
+
+		if len(phrase.Libraries) == 0 {
+			for _, id := range index.wordIDs(phrase.English) {
+				phrase.Libraries = append(phrase.Libraries, &models.Library{ID: id})
+			}
+		}
+
+		if err := ls.PhraseRepository.UpsertPhrase(ctx, phrase); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// phraseIndex holds the library words by their forms, so the words of a
+// sentence are found by looking its tokens up.
+type phraseIndex struct {
+	ids      map[string][]int
+	maxWords int
+}
+
+func newPhraseIndex(library []*models.Library) *phraseIndex {
+	index := &phraseIndex{ids: make(map[string][]int)}
+	for _, libWord := range library {
+		for _, form := range wordForms(mappers.MapLibraryToWord(libWord)) {
+			tokens := phraseTokens(form)
+			if len(tokens) == 0 {
+				continue
+			}
+
+			key := strings.Join(tokens, " ")
+			index.ids[key] = append(index.ids[key], libWord.ID)
+			if len(tokens) > index.maxWords {
+				index.maxWords = len(tokens)
+			}
+		}
+	}
+
+	return index
+}
+
+// wordIDs returns the ids of the words whose forms are in the sentence, a
+// form of several words matching consecutive tokens.
+func (pi *phraseIndex) wordIDs(sentence string) []int {
+	tokens := phraseTokens(sentence)
+	seen := make(map[int]bool)
+	ids := []int{}
+	for i := range tokens {
+		for n := 1; n <= pi.maxWords && i+n <= len(tokens); n++ {
+			for _, id := range pi.ids[strings.Join(tokens[i:i+n], " ")] {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+func phraseTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+}
@@ -0,0 +1,206 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agnivade/levenshtein"
+)
+
+const (
+	duplicateExact = "exact"
+	duplicateFuzzy = "fuzzy"
+)
+
+func (ls *libraryInteractor) FindDuplicates(ctx context.Context) ([]*models.DuplicateGroup, error) {
+	words, err := ls.LibraryRepository.GetAllWords()
+	if err != nil {
+		return nil, err
+	}
+
+	return findDuplicates(words), nil
+}
+
+func (ls *libraryInteractor) MergeDuplicates(ctx context.Context, canonicalID string, duplicateIDs []string) error {
+	canonical, err := strconv.Atoi(canonicalID)
+	if err != nil {
+		return apperrors.MergeDuplicatesErr.AppendMessage(err)
+	}
+
+	if _, err := ls.LibraryRepository.GetWordByID(ctx, canonical); err != nil {
+		return err
+	}
+
+	duplicates := []int{}
+	seen := map[int]bool{}
+	for _, duplicateID := range duplicateIDs {
+		id, err := strconv.Atoi(duplicateID)
+		if err != nil {
+			return apperrors.MergeDuplicatesErr.AppendMessage(err)
+		}
+
+		if id == canonical {
+			return apperrors.MergeDuplicatesErr.AppendMessage("the word kept", id, "is among the duplicates")
+		}
+
+		if seen[id] {
+			continue
+		}
+
+		if _, err := ls.LibraryRepository.GetWordByID(ctx, id); err != nil {
+			return err
+		}
+
+		seen[id] = true
+		duplicates = append(duplicates, id)
+	}
+
+	if len(duplicates) == 0 {
+		return apperrors.MergeDuplicatesErr.AppendMessage("nothing to merge")
+	}
+
+	if err := ls.LibraryRepository.MergeWords(ctx, canonical, duplicates); err != nil {
+		return err
+	}
+
+	return ls.refreshWordsMaps()
+}
+
+// findDuplicates groups words whose normalized English and Russian are equal
+// (exact) or where one side is equal and the other is within a small edit
+// distance (fuzzy).
+func findDuplicates(words []*models.Library) []*models.DuplicateGroup {
+	parent := make([]int, len(words))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	fuzzy := make(map[int]bool)
+	union := func(i, j int, exact bool) {
+		ri, rj := find(i), find(j)
+		if ri != rj {
+			parent[rj] = ri
+			fuzzy[ri] = fuzzy[ri] || fuzzy[rj]
+		}
+
+		if !exact {
+			fuzzy[ri] = true
+		}
+	}
+
+	english := make([]string, len(words))
+	russian := make([]string, len(words))
+	byEnglish := make(map[string][]int)
+	byRussian := make(map[string][]int)
+	for i, word := range words {
+		english[i] = normalizeWord(word.English)
+		russian[i] = normalizeWord(word.Russian)
+		byEnglish[english[i]] = append(byEnglish[english[i]], i)
+		byRussian[russian[i]] = append(byRussian[russian[i]], i)
+	}
+
+	for _, indexes := range byRussian {
+		for a := 0; a < len(indexes); a++ {
+			for b := a + 1; b < len(indexes); b++ {
+				i, j := indexes[a], indexes[b]
+				if english[i] == english[j] {
+					union(i, j, true)
+					continue
+				}
+
+				if isNearDuplicate(english[i], english[j]) {
+					union(i, j, false)
+				}
+			}
+		}
+	}
+
+	for _, indexes := range byEnglish {
+		for a := 0; a < len(indexes); a++ {
+			for b := a + 1; b < len(indexes); b++ {
+				i, j := indexes[a], indexes[b]
+				if russian[i] != russian[j] && isNearDuplicate(russian[i], russian[j]) {
+					union(i, j, false)
+				}
+			}
+		}
+	}
+
+	groupsByRoot := make(map[int]*models.DuplicateGroup)
+	for i, word := range words {
+		root := find(i)
+		group, ok := groupsByRoot[root]
+		if !ok {
+			group = &models.DuplicateGroup{Kind: duplicateExact}
+			groupsByRoot[root] = group
+		}
+
+		group.Words = append(group.Words, word)
+	}
+
+	groups := []*models.DuplicateGroup{}
+	for root, group := range groupsByRoot {
+		if len(group.Words) < 2 {
+			continue
+		}
+
+		if fuzzy[root] {
+			group.Kind = duplicateFuzzy
+		}
+
+		sort.Slice(group.Words, func(i, j int) bool { return group.Words[i].ID < group.Words[j].ID })
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Kind != groups[j].Kind {
+			return groups[i].Kind == duplicateExact
+		}
+
+		return groups[i].Words[0].ID < groups[j].Words[0].ID
+	})
+
+	return groups
+}
+
+func isNearDuplicate(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	mistakes := 1
+	if len([]rune(a)) > 6 && len([]rune(b)) > 6 {
+		mistakes = 2
+	}
+
+	return levenshtein.ComputeDistance(a, b) <= mistakes
+}
+
+func normalizeWord(word string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if r == 'ё' {
+			r = 'е'
+		}
+
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			normalized.WriteRune(r)
+		}
+	}
+
+	return normalized.String()
+}
@@ -0,0 +1,120 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/domain/models"
+	"server/internal/usercase/morphology"
+	"strings"
+)
+
+// maxGlossPhraseWords is the longest multi-word entry, such as a phrasal
+// verb, a gloss tries to match.
+const maxGlossPhraseWords = 4
+
+// Gloss splits the text into words and translates them one by one, each in
+// the direction of its own language, so a sentence may mix both languages of
+// the pair. Multi-word library entries are matched first, the longest one at
+// each position wins.
+func (ls *libraryInteractor) Gloss(ctx context.Context, text string, pair models.LanguagePair) ([]*models.GlossToken, error) {
+	if err := validateLanguagePair(pair); err != nil {
+		return nil, err
+	}
+
+	words := morphology.Tokenize(text)
+	directions := make([][]models.LanguagePair, len(words))
+	supported := false
+	for i, word := range words {
+		var detected []string
+		words[i], detected = detectLanguages(word)
+		directions[i] = pairDirections(detected, pair)
+		supported = supported || len(directions[i]) > 0
+	}
+
+	if !supported {
+		text, detected := detectLanguages(strings.TrimSpace(text))
+		return nil, unsupportedLanguage(text, detected, pair)
+	}
+
+	gloss := []*models.GlossToken{}
+	for i := 0; i < len(words); {
+		n := maxGlossPhraseWords
+		if len(words)-i < n {
+			n = len(words) - i
+		}
+
+		for ; n > 0; n-- {
+			if token := ls.glossSpan(words[i:i+n], directions[i:i+n]); token != nil {
+				gloss = append(gloss, token)
+				break
+			}
+		}
+
+		if n == 0 {
+			gloss = append(gloss, &models.GlossToken{Text: words[i]})
+			n = 1
+		}
+
+		i += n
+	}
+
+	return gloss, nil
+}
+
+// glossSpan tries the words as one entry in every direction all of them can
+// be read in, the most likely for the first word first.
+func (ls *libraryInteractor) glossSpan(words []string, directions [][]models.LanguagePair) *models.GlossToken {
+	for _, direction := range directions[0] {
+		if !allIn(direction, directions[1:]) {
+			continue
+		}
+
+		if token := ls.glossToken(words, direction); token != nil {
+			return token
+		}
+	}
+
+	return nil
+}
+
+func allIn(direction models.LanguagePair, directions [][]models.LanguagePair) bool {
+	for _, word := range directions {
+		found := false
+		for _, other := range word {
+			found = found || other == direction
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// glossToken translates the words as one entry, the first word may be
+// inflected as in "picked up". It returns nil when they are not one.
+func (ls *libraryInteractor) glossToken(words []string, direction models.LanguagePair) *models.GlossToken {
+	text := strings.Join(words, " ")
+	lemmas := ls.LemmaRepository.SearchLemmas(direction.Source, text, false, 1)
+	if len(lemmas) == 0 && len(words) == 1 {
+		lemmas, _ = ls.morphLemmas(direction.Source, text)
+	}
+
+	if len(lemmas) == 0 && len(words) > 1 {
+		rest := strings.Join(words[1:], " ")
+		for _, form := range morphology.Lemmatize(direction.Source, words[0]) {
+			if lemmas = ls.LemmaRepository.SearchLemmas(direction.Source, form+" "+rest, false, 1); len(lemmas) > 0 {
+				break
+			}
+		}
+	}
+
+	for _, lemma := range lemmas {
+		translations := ls.LemmaRepository.ConceptLemmas(lemma.LibraryID)[direction.Target]
+		if len(translations) > 0 {
+			return &models.GlossToken{Text: text, Lemma: lemma.Text, Translation: translations[0], LibraryID: lemma.LibraryID}
+		}
+	}
+
+	return nil
+}
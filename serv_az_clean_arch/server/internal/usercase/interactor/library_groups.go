@@ -0,0 +1,208 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"strconv"
+	"strings"
+)
+
+func (ls *libraryInteractor) GetTranslationGroups(ctx context.Context) ([]*models.TranslationGroup, error) {
+	return ls.GroupRepository.GetAllGroups(ctx)
+}
+
+func (ls *libraryInteractor) CreateTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apperrors.TranslationGroupErr.AppendMessage("group name is empty")
+	}
+
+	if _, err := ls.GroupRepository.GetGroupByName(ctx, name); err == nil {
+		return apperrors.TranslationGroupErr.AppendMessage("group already exists", name)
+	}
+
+	return ls.GroupRepository.CreateGroup(ctx, &models.TranslationGroup{Name: name})
+}
+
+func (ls *libraryInteractor) DeleteTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error {
+	groupID, err := strconv.Atoi(req.GroupID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	if err := ls.GroupRepository.DeleteGroup(ctx, groupID); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+func (ls *libraryInteractor) AddWordToTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error {
+	groupID, err := strconv.Atoi(req.GroupID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	wordID, err := strconv.Atoi(req.WordID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	if _, err := ls.GroupRepository.GetGroupByID(ctx, groupID); err != nil {
+		return err
+	}
+
+	if err := ls.GroupRepository.SetWordGroup(ctx, wordID, groupID); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+func (ls *libraryInteractor) RemoveWordFromTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error {
+	wordID, err := strconv.Atoi(req.WordID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	if err := ls.GroupRepository.SetWordGroup(ctx, wordID, 0); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+func (ls *libraryInteractor) AddTranslationVariant(ctx context.Context, req *requests.TranslationGroupRequest) error {
+	groupID, err := strconv.Atoi(req.GroupID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	english := strings.TrimSpace(req.English)
+	if english == "" {
+		return apperrors.TranslationGroupErr.AppendMessage("variant is empty")
+	}
+
+	if _, err := ls.GroupRepository.GetGroupByID(ctx, groupID); err != nil {
+		return err
+	}
+
+	variant := &models.TranslationVariant{TranslationGroupID: groupID, English: capitalizeFirstRune(english)}
+	if err := ls.GroupRepository.AddVariant(ctx, variant); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+func (ls *libraryInteractor) DeleteTranslationVariant(ctx context.Context, variantID string) error {
+	id, err := strconv.Atoi(variantID)
+	if err != nil {
+		return apperrors.TranslationGroupErr.AppendMessage(err)
+	}
+
+	if err := ls.GroupRepository.DeleteVariant(ctx, id); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+// AddAnswerToWordGroup accepts english as an answer for the word, putting the
+// word into a group named after it when it has none yet.
+func (ls *libraryInteractor) AddAnswerToWordGroup(ctx context.Context, wordID int, english string) error {
+	english = capitalizeFirstRune(strings.TrimSpace(english))
+	if english == "" {
+		return apperrors.TranslationGroupErr.AppendMessage("answer is empty")
+	}
+
+	word, err := ls.LibraryRepository.GetWordByID(ctx, wordID)
+	if err != nil {
+		return err
+	}
+
+	if word.TranslationGroupID == 0 {
+		word.TranslationGroupName = word.English
+		if err := ls.resolveTranslationGroups(ctx, []*models.Library{word}); err != nil {
+			return err
+		}
+
+		if err := ls.GroupRepository.SetWordGroup(ctx, word.ID, word.TranslationGroupID); err != nil {
+			return err
+		}
+	}
+
+	group, err := ls.GroupRepository.GetGroupByID(ctx, word.TranslationGroupID)
+	if err != nil {
+		return err
+	}
+
+	known := strings.EqualFold(word.English, english)
+	for _, variant := range group.Variants {
+		known = known || strings.EqualFold(variant.English, english)
+	}
+
+	if !known {
+		variant := &models.TranslationVariant{TranslationGroupID: group.ID, English: english}
+		if err := ls.GroupRepository.AddVariant(ctx, variant); err != nil {
+			return err
+		}
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+// resolveTranslationGroups turns TranslationGroupName into TranslationGroupID,
+// creating groups that don't exist yet.
+func (ls *libraryInteractor) resolveTranslationGroups(ctx context.Context, words []*models.Library) error {
+	groups, err := ls.GroupRepository.GetAllGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	groupIDs := make(map[string]int, len(groups))
+	for _, group := range groups {
+		groupIDs[group.Name] = group.ID
+	}
+
+	for _, word := range words {
+		if word.TranslationGroupName == "" {
+			continue
+		}
+
+		groupID, ok := groupIDs[word.TranslationGroupName]
+		if !ok {
+			group := &models.TranslationGroup{Name: word.TranslationGroupName}
+			if err := ls.GroupRepository.CreateGroup(ctx, group); err != nil {
+				return err
+			}
+
+			groupID = group.ID
+			groupIDs[group.Name] = groupID
+		}
+
+		word.TranslationGroupID = groupID
+	}
+
+	return nil
+}
+
+func (ls *libraryInteractor) fillTranslationGroupNames(ctx context.Context, words []*models.Library) error {
+	groups, err := ls.GroupRepository.GetAllGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	groupNames := make(map[int]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+
+	for _, word := range words {
+		word.TranslationGroupName = groupNames[word.TranslationGroupID]
+	}
+
+	return nil
+}
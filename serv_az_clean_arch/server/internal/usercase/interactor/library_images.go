@@ -0,0 +1,192 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path"
+	"server/internal/apperrors"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxImageBytes  = 5 << 20
+	maxImagePixels = 40_000_000
+	// maxZipImages and maxZipBytes bound an archive of pictures, counted by
+	// what is actually read rather than by the sizes the archive claims.
+	maxZipImages = 2000
+	maxZipBytes  = 500 << 20
+)
+
+// SetWordImage stores the picture and attaches it to the library word.
+func (ls *libraryInteractor) SetWordImage(ctx context.Context, id string, data []byte) error {
+	wordID, err := strconv.Atoi(id)
+	if err != nil {
+		return apperrors.ImageErr.AppendMessage("wrong word id", id)
+	}
+
+	if _, err := ls.LibraryRepository.GetWordByID(ctx, wordID); err != nil {
+		return err
+	}
+
+	return ls.saveWordImage(ctx, wordID, data)
+}
+
+// DeleteWordImage detaches the picture from the word. The file stays on disk
+// since other words may share it.
+func (ls *libraryInteractor) DeleteWordImage(ctx context.Context, id string) error {
+	wordID, err := strconv.Atoi(id)
+	if err != nil {
+		return apperrors.ImageErr.AppendMessage("wrong word id", id)
+	}
+
+	return ls.LibraryRepository.SetWordImage(ctx, wordID, "")
+}
+
+// ImportImagesZIP attaches the pictures of a ZIP archive named by word ID,
+// e.g. 42.jpg. A first pass reads and decodes every picture without keeping
+// it, a second one reads them again to save them, so one picture at a time is
+// held in memory. The words get their pictures in one transaction, so a bad
+// file attaches nothing.
+func (ls *libraryInteractor) ImportImagesZIP(ctx context.Context, file io.ReaderAt, size int64) (int, error) {
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return 0, apperrors.ImportImagesErr.AppendMessage(err)
+	}
+
+	images := make(map[int]*wordImage)
+	var total int64
+	for _, entry := range archive.File {
+		name := path.Base(entry.Name)
+		if entry.FileInfo().IsDir() || strings.HasPrefix(name, ".") || strings.HasPrefix(entry.Name, "__MACOSX/") {
+			continue
+		}
+
+		wordID, err := strconv.Atoi(strings.TrimSuffix(name, path.Ext(name)))
+		if err != nil {
+			return 0, apperrors.ImportImagesErr.AppendMessage("file name is not a word id", entry.Name)
+		}
+
+		if _, ok := images[wordID]; ok {
+			return 0, apperrors.ImportImagesErr.AppendMessage("several images for word", wordID)
+		}
+
+		if entry.UncompressedSize64 > maxImageBytes {
+			return 0, apperrors.ImportImagesErr.AppendMessage("image is too big", entry.Name)
+		}
+
+		if len(images) == maxZipImages {
+			return 0, apperrors.ImportImagesErr.AppendMessage("more images than", maxZipImages)
+		}
+
+		if _, err := ls.LibraryRepository.GetWordByID(ctx, wordID); err != nil {
+			return 0, apperrors.ImportImagesErr.AppendMessage("there is no word for", entry.Name)
+		}
+
+		data, err := readZipFile(entry)
+		if err != nil {
+			return 0, apperrors.ImportImagesErr.AppendMessage(entry.Name, err)
+		}
+
+		total += int64(len(data))
+		if total > maxZipBytes {
+			return 0, apperrors.ImportImagesErr.AppendMessage("images are bigger than", maxZipBytes>>20, "MB")
+		}
+
+		format, err := decodeImage(data)
+		if err != nil {
+			return 0, apperrors.ImportImagesErr.AppendMessage(entry.Name, err)
+		}
+
+		images[wordID] = &wordImage{entry: entry, format: format}
+	}
+
+	names := make(map[int]string, len(images))
+	for wordID, img := range images {
+		data, err := readZipFile(img.entry)
+		if err != nil {
+			return 0, apperrors.ImportImagesErr.AppendMessage(img.entry.Name, err)
+		}
+
+		name, err := ls.ImageRepository.SaveImage(data, img.format)
+		if err != nil {
+			return 0, err
+		}
+
+		names[wordID] = name
+	}
+
+	if err := ls.LibraryRepository.SetWordImages(ctx, names); err != nil {
+		return 0, err
+	}
+
+	return len(names), nil
+}
+
+type wordImage struct {
+	entry  *zip.File
+	format string
+}
+
+func (ls *libraryInteractor) saveWordImage(ctx context.Context, wordID int, data []byte) error {
+	format, err := decodeImage(data)
+	if err != nil {
+		return err
+	}
+
+	name, err := ls.ImageRepository.SaveImage(data, format)
+	if err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.SetWordImage(ctx, wordID, name)
+}
+
+// decodeImage checks the size of the picture and decodes it whole, so broken
+// files are caught before anything is saved. It returns the image format.
+func decodeImage(data []byte) (string, error) {
+	if len(data) > maxImageBytes {
+		return "", apperrors.ImageErr.AppendMessage("image is bigger than", maxImageBytes>>20, "MB")
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", apperrors.ImageErr.AppendMessage("jpeg, png or gif expected", err)
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		return "", apperrors.ImageErr.AppendMessage("image is too large", config.Width, config.Height)
+	}
+
+	if _, _, err := image.Decode(bytes.NewReader(data)); err != nil {
+		return "", apperrors.ImageErr.AppendMessage("broken image", err)
+	}
+
+	return format, nil
+}
+
+func readZipFile(entry *zip.File) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxImageBytes {
+		return nil, apperrors.ImageErr.AppendMessage("image is bigger than", maxImageBytes>>20, "MB")
+	}
+
+	return data, nil
+}
@@ -2,127 +2,155 @@ package interactor
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 	"os"
 	"server/internal/apperrors"
 	"server/internal/domain/mappers"
 	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/domain/responses"
 	"server/internal/usercase/repository"
+	"strconv"
+
+	"github.com/tealeg/xlsx"
 )
 
 type libraryInteractor struct {
 	LibraryRepository repository.LibraryRepository
 	WordsRepository   repository.WordsRepository
 	BackupRepository  repository.BackUpCopyRepo
+	GroupRepository   repository.TranslationGroupRepository
+	TopicRepository   repository.TopicRepository
+	PhraseRepository  repository.PhraseRepository
+	ImageRepository   repository.ImageRepository
+	LemmaRepository   repository.LemmaRepository
 }
 
 type LibraryInteractor interface {
-	GetTranslationByWord(ctx context.Context, translReq string) ([]*models.Library, error)
-	GetTranslationByPieceOfWord(ctx context.Context, translReq string) (string, error)
+	AnalyzeText(ctx context.Context, text string, pair models.LanguagePair) (*models.TextAnalysis, error)
+	Gloss(ctx context.Context, text string, pair models.LanguagePair) ([]*models.GlossToken, error)
+	DidYouMean(ctx context.Context, text string, pair models.LanguagePair) ([]string, error)
+	Suggest(ctx context.Context, text string, pair models.LanguagePair, limit int) ([]*responses.Suggestion, error)
 	UpdateLibraryOldAndNewWordsByMultyFile(ctx context.Context, file *multipart.File) error
-	DownloadXLXFromDb() (*os.File, error)
+	SeedLibrary(ctx context.Context, path string) (int, error)
+	DownloadXLXFromDb(ctx context.Context) (*os.File, error)
 	GetAllTopics() ([]string, error)
+	GetLibraryPage(ctx context.Context, filter *requests.LibraryFilterRequest) ([]*models.Library, int64, error)
+	GetAllPartsOfSpeech() ([]string, error)
+	GetLibraryWord(ctx context.Context, id int) (*models.Library, error)
+	CreateLibraryWord(ctx context.Context, req *requests.LibraryWordRequest) (*models.Library, error)
+	UpdateLibraryWord(ctx context.Context, authorID string, req *requests.LibraryWordRequest) error
+	DeleteLibraryWord(ctx context.Context, id string) error
+	FindDuplicates(ctx context.Context) ([]*models.DuplicateGroup, error)
+	MergeDuplicates(ctx context.Context, canonicalID string, duplicateIDs []string) error
+	GetTranslationGroups(ctx context.Context) ([]*models.TranslationGroup, error)
+	CreateTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error
+	DeleteTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error
+	AddWordToTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error
+	RemoveWordFromTranslationGroup(ctx context.Context, req *requests.TranslationGroupRequest) error
+	AddTranslationVariant(ctx context.Context, req *requests.TranslationGroupRequest) error
+	DeleteTranslationVariant(ctx context.Context, variantID string) error
+	AddAnswerToWordGroup(ctx context.Context, wordID int, english string) error
+	GetTopicTree(ctx context.Context) ([]*models.Topic, error)
+	GetTopicWithSubtopics(ctx context.Context, slug string) (*models.Topic, []int, error)
+	CreateTopic(ctx context.Context, req *requests.TopicRequest) error
+	UpdateTopic(ctx context.Context, req *requests.TopicRequest) error
+	DeleteTopic(ctx context.Context, id string) error
+	SyncTopics(ctx context.Context) (int, error)
+	BuildClozeTest(ctx context.Context, words []*models.Word, limit int) ([]*models.Word, error)
+	SetWordImage(ctx context.Context, id string, data []byte) error
+	DeleteWordImage(ctx context.Context, id string) error
+	ImportImagesZIP(ctx context.Context, file io.ReaderAt, size int64) (int, error)
+	SyncLemmas(ctx context.Context) (int, error)
+	Translate(ctx context.Context, text string, pair models.LanguagePair) (*models.TranslationLookup, error)
+	LocalizeWords(words []*models.Word, pair models.LanguagePair) ([]*models.Word, []*models.Word)
+	MatchLemma(language, text string) *models.Lemma
+	GetWordLemmas(ctx context.Context, wordID string) ([]*models.Lemma, error)
+	CountLemmas(ctx context.Context) (map[string]int64, error)
+	AddLemma(ctx context.Context, req *requests.LemmaRequest) error
+	AddRussianAnswer(ctx context.Context, wordID int, russian string) error
+	DeleteLemma(ctx context.Context, id string) error
 }
 
-func NewLibraryInteractor(u repository.LibraryRepository, w repository.WordsRepository, b repository.BackUpCopyRepo) LibraryInteractor {
-	return &libraryInteractor{LibraryRepository: u, WordsRepository: w, BackupRepository: b}
+func NewLibraryInteractor(u repository.LibraryRepository, w repository.WordsRepository, b repository.BackUpCopyRepo, g repository.TranslationGroupRepository, t repository.TopicRepository, p repository.PhraseRepository, i repository.ImageRepository, l repository.LemmaRepository) LibraryInteractor {
+	return &libraryInteractor{LibraryRepository: u, WordsRepository: w, BackupRepository: b, GroupRepository: g, TopicRepository: t, PhraseRepository: p, ImageRepository: i, LemmaRepository: l}
 }
 
-func (ls *libraryInteractor) GetTranslationByWord(ctx context.Context, translReq string) ([]*models.Library, error) {
-	capitalizedWord := capitalizeFirstRune(translReq)
-	if isCyrillic(capitalizedWord) {
-		words, err := ls.LibraryRepository.GetTranslationRus(capitalizedWord)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(words) == 0 {
-			words, err = ls.LibraryRepository.GetTranslationRusLike(capitalizedWord)
-			if err != nil {
-				return nil, err
-			}
+func (ls *libraryInteractor) UpdateLibraryOldAndNewWordsByMultyFile(ctx context.Context, file *multipart.File) error {
+	fileXLS, err := mappers.MapMultipartToXLS(file)
+	if err != nil {
+		return err
+	}
 
-		}
+	_, err = ls.importXLS(ctx, fileXLS)
+	return err
+}
 
-		return words, nil
+// SeedLibrary imports the library file at path unless a seed has finished.
+// The import writes row by row, so a seed that failed halfway runs again: it
+// rewrites the rows already there with the same values and adds the rest.
+func (ls *libraryInteractor) SeedLibrary(ctx context.Context, path string) (int, error) {
+	seeded, err := ls.LibraryRepository.IsLibrarySeeded(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	if !isCyrillic(capitalizedWord) {
-		words, err := ls.LibraryRepository.GetTranslationEngl(capitalizedWord)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(words) == 0 {
-			words, err = ls.LibraryRepository.GetTranslationEnglLike(capitalizedWord)
-			if err != nil {
-				return nil, err
-			}
+	if seeded {
+		return 0, nil
+	}
 
-		}
+	fileXLS, err := mappers.MapPathToXLS(path)
+	if err != nil {
+		appErr := apperrors.SeedLibraryErr.AppendMessage(err)
+		return 0, appErr
+	}
 
-		return words, nil
+	count, err := ls.importXLS(ctx, fileXLS)
+	if err != nil {
+		return 0, err
 	}
 
-	appErr := apperrors.GetTranslationByWordErr.AppendMessage("this word isn't rus or english, try to change your language")
+	if err := ls.LibraryRepository.MarkLibrarySeeded(ctx, &models.LibrarySeed{Path: path, Words: count}); err != nil {
+		return 0, err
+	}
 
-	return nil, appErr
+	return count, nil
 }
 
-func (ls *libraryInteractor) GetTranslationByPieceOfWord(ctx context.Context, translReq string) (string, error) {
-	capitalizedWord := capitalizeFirstRune(translReq)
-	if isCyrillic(capitalizedWord) {
-		words, err := ls.LibraryRepository.GetTranslationRusLikeWord(capitalizedWord)
-		if err != nil {
-			//ls.log.Error(err)
-			return "", err
-		}
-
-		return words.Russian, nil
+func (ls *libraryInteractor) importXLS(ctx context.Context, fileXLS *xlsx.File) (int, error) {
+	librUpdate := mappers.MapXLStoLibrary(fileXLS)
+	if err := mappers.ValidateLibrary(librUpdate); err != nil {
+		return 0, err
 	}
 
-	if !isCyrillic(capitalizedWord) {
-		words, err := ls.LibraryRepository.GetTranslationEnglLikeWord(capitalizedWord)
-		if err != nil {
-			//ls.log.Error(err)
-			return "", err
-		}
-
-		return words.English, nil
+	if err := ls.resolveTranslationGroups(ctx, librUpdate); err != nil {
+		return 0, err
 	}
 
-	appErr := apperrors.GetTranslationByWordErr.AppendMessage("this word isn't rus or english, try to change your language")
-	//ls.log.Error(appErr)
-	return "", appErr
-}
-
-func (ls *libraryInteractor) UpdateLibraryOldAndNewWordsByMultyFile(ctx context.Context, file *multipart.File) error {
-	fileXLS, err := mappers.MapMultipartToXLS(file)
-	if err != nil {
-		return err
+	if err := ls.resolveTopics(ctx, librUpdate); err != nil {
+		return 0, err
 	}
 
-	librUpdate := mappers.MapXLStoLibrary(fileXLS)
-
 	for _, word := range librUpdate {
-		err := ls.LibraryRepository.UpdateWord(ctx, word)
+		err := ls.LibraryRepository.UpdateWord(ctx, word, "")
 		if err != nil {
 			if err == &apperrors.UpdateWordRowAffectedErr {
 				err := ls.LibraryRepository.InsertWordLibrary(ctx, word)
 				if err != nil {
-					return err
+					return 0, err
 				}
 
 				continue
 			}
 
-			return err
+			return 0, err
 		}
 	}
 	//
-	err = ls.LibraryRepository.UpdateWordsMap()
+	err := ls.refreshWordsMaps()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	wordsUpdate := mappers.MapXLStoWords(fileXLS)
@@ -132,26 +160,51 @@ func (ls *libraryInteractor) UpdateLibraryOldAndNewWordsByMultyFile(ctx context.
 			if err == &apperrors.UpdateWordRowAffectedErr {
 				err := ls.WordsRepository.InsertWord(ctx, word)
 				if err != nil {
-					return err
+					return 0, err
 				}
 
 				continue
 			}
 
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	if err := ls.importPhrases(ctx, fileXLS); err != nil {
+		return 0, err
+	}
+
+	if err := ls.importLemmas(ctx, fileXLS); err != nil {
+		return 0, err
+	}
+
+	return len(librUpdate), nil
 }
 
-func (ls *libraryInteractor) DownloadXLXFromDb() (*os.File, error) {
+func (ls *libraryInteractor) DownloadXLXFromDb(ctx context.Context) (*os.File, error) {
 	words, err := ls.LibraryRepository.GetAllWords()
 	if err != nil {
 		return nil, err
 	}
 
-	ls.BackupRepository.SaveWordsAsXLSX(words)
+	if err := ls.fillTranslationGroupNames(ctx, words); err != nil {
+		return nil, err
+	}
+
+	phrases, err := ls.PhraseRepository.GetAllPhrases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lemmas, err := ls.LemmaRepository.GetExtraLemmas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ls.BackupRepository.SaveWordsAsXLSX(words, phrases, lemmas); err != nil {
+		return nil, err
+	}
+
 	return ls.BackupRepository.OpenFile()
 }
 
@@ -175,3 +228,159 @@ func (ls *libraryInteractor) GetAllTopics() ([]string, error) {
 
 	return topicsWithoutWhiteSpace, nil
 }
+
+const (
+	libraryPageSize    = 20
+	libraryMaxPageSize = 100
+)
+
+func (ls *libraryInteractor) GetLibraryPage(ctx context.Context, filter *requests.LibraryFilterRequest) ([]*models.Library, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+
+	if filter.PageSize < 1 {
+		filter.PageSize = libraryPageSize
+	}
+
+	if filter.PageSize > libraryMaxPageSize {
+		filter.PageSize = libraryMaxPageSize
+	}
+
+	words, total, err := ls.LibraryRepository.GetWordsByFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := ls.fillTranslationGroupNames(ctx, words); err != nil {
+		return nil, 0, err
+	}
+
+	return words, total, nil
+}
+
+func (ls *libraryInteractor) GetAllPartsOfSpeech() ([]string, error) {
+	partsOfSpeech, err := ls.LibraryRepository.GetAllPartsOfSpeech()
+	if err != nil {
+		return nil, err
+	}
+
+	partsWithoutWhiteSpace := []string{}
+	for _, part := range partsOfSpeech {
+		if part != "" {
+			partsWithoutWhiteSpace = append(partsWithoutWhiteSpace, part)
+		}
+	}
+
+	return partsWithoutWhiteSpace, nil
+}
+
+// GetLibraryWord returns the word with the name of its translation group, the
+// way the library forms show it.
+func (ls *libraryInteractor) GetLibraryWord(ctx context.Context, id int) (*models.Library, error) {
+	word, err := ls.LibraryRepository.GetWordByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ls.fillTranslationGroupNames(ctx, []*models.Library{word}); err != nil {
+		return nil, err
+	}
+
+	return word, nil
+}
+
+func (ls *libraryInteractor) CreateLibraryWord(ctx context.Context, req *requests.LibraryWordRequest) (*models.Library, error) {
+	word, err := mappers.MapLibraryWordRequestToLibrary(req)
+	if err != nil {
+		return nil, apperrors.CreateLibraryWordErr.AppendMessage(err)
+	}
+
+	if word.ID == 0 {
+		maxID, err := ls.LibraryRepository.GetMaxID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		word.ID = maxID + 1
+	}
+
+	if err := mappers.ValidateLibrary([]*models.Library{word}); err != nil {
+		return nil, apperrors.CreateLibraryWordErr.AppendMessage(err)
+	}
+
+	if _, err := ls.LibraryRepository.GetWordByID(ctx, word.ID); err == nil {
+		return nil, apperrors.CreateLibraryWordErr.AppendMessage("id is already used", word.ID)
+	}
+
+	if err := ls.resolveTranslationGroups(ctx, []*models.Library{word}); err != nil {
+		return nil, err
+	}
+
+	if err := ls.resolveTopics(ctx, []*models.Library{word}); err != nil {
+		return nil, err
+	}
+
+	if err := ls.LibraryRepository.InsertWordLibrary(ctx, word); err != nil {
+		return nil, err
+	}
+
+	if err := ls.WordsRepository.InsertWord(ctx, mappers.MapLibraryToWord(word)); err != nil {
+		return nil, err
+	}
+
+	if err := ls.refreshWordsMaps(); err != nil {
+		return nil, err
+	}
+
+	return word, nil
+}
+
+// UpdateLibraryWord writes the word from the library form, credited to
+// authorID.
+func (ls *libraryInteractor) UpdateLibraryWord(ctx context.Context, authorID string, req *requests.LibraryWordRequest) error {
+	word, err := mappers.MapLibraryWordRequestToLibrary(req)
+	if err != nil {
+		return apperrors.UpdateLibraryWordErr.AppendMessage(err)
+	}
+
+	if err := mappers.ValidateLibrary([]*models.Library{word}); err != nil {
+		return apperrors.UpdateLibraryWordErr.AppendMessage(err)
+	}
+
+	if err := ls.resolveTranslationGroups(ctx, []*models.Library{word}); err != nil {
+		return err
+	}
+
+	if err := ls.resolveTopics(ctx, []*models.Library{word}); err != nil {
+		return err
+	}
+
+	if err := ls.LibraryRepository.UpdateWord(ctx, word, authorID); err != nil {
+		return err
+	}
+
+	err = ls.WordsRepository.UpdateWord(ctx, mappers.MapLibraryToWord(word))
+	if err == &apperrors.UpdateWordRowAffectedErr {
+		err = ls.WordsRepository.InsertWord(ctx, mappers.MapLibraryToWord(word))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return ls.refreshWordsMaps()
+}
+
+func (ls *libraryInteractor) DeleteLibraryWord(ctx context.Context, id string) error {
+	wordID, err := strconv.Atoi(id)
+	if err != nil {
+		return apperrors.DeleteLibraryWordErr.AppendMessage(err)
+	}
+
+	if err := ls.LibraryRepository.DeleteWord(ctx, wordID); err != nil {
+		return err
+	}
+
+	return ls.refreshWordsMaps()
+}
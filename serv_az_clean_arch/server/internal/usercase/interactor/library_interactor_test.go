@@ -0,0 +1,124 @@
+package interactor
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"path/filepath"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+	"strconv"
+	"testing"
+)
+
+func (f *fakeLibraryRepository) IsLibrarySeeded(ctx context.Context) (bool, error) {
+	return len(f.seeds) > 0, nil
+}
+
+func (f *fakeLibraryRepository) MarkLibrarySeeded(ctx context.Context, seed *models.LibrarySeed) error {
+	f.seeds = append(f.seeds, seed)
+	return nil
+}
+
+func TestSeedLibrary(t *testing.T) {
+	tests := []struct {
+		name    string
+		seeds   []*models.LibrarySeed
+		wantErr bool
+		marked  int
+	}{
+		{name: "seeded before", seeds: []*models.LibrarySeed{{Words: 10}}, marked: 1},
+		{name: "failed seed is not marked", wantErr: true, marked: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			library := &fakeLibraryRepository{seeds: tt.seeds}
+			ls := &libraryInteractor{LibraryRepository: library}
+
+			count, err := ls.SeedLibrary(context.Background(), filepath.Join(t.TempDir(), "missing.xlsx"))
+			if (err != nil) != tt.wantErr || count != 0 {
+				t.Fatalf("SeedLibrary() = %d, %v, want 0, error %v", count, err, tt.wantErr)
+			}
+
+			if len(library.seeds) != tt.marked {
+				t.Errorf("SeedLibrary() seeds = %d, want %d", len(library.seeds), tt.marked)
+			}
+		})
+	}
+}
+
+func (f *fakeLibraryRepository) SetWordImages(ctx context.Context, images map[int]string) error {
+	f.images = images
+	return nil
+}
+
+type fakeImageRepository struct {
+	repository.ImageRepository
+	saved int
+}
+
+func (f *fakeImageRepository) SaveImage(data []byte, format string) (string, error) {
+	f.saved++
+	return strconv.Itoa(f.saved) + "." + format, nil
+}
+
+func TestImportImagesZIP(t *testing.T) {
+	picture := &bytes.Buffer{}
+	if err := png.Encode(picture, image.NewGray(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string][]byte
+		wantErr bool
+		saved   int
+	}{
+		{name: "pictures", files: map[string][]byte{"1.png": picture.Bytes(), "dir/2.png": picture.Bytes(), "__MACOSX/._1.png": {0}}, saved: 2},
+		{name: "broken picture saves nothing", files: map[string][]byte{"1.png": picture.Bytes(), "2.png": []byte("not a picture")}, wantErr: true},
+		{name: "file not named by a word id", files: map[string][]byte{"cat.png": picture.Bytes()}, wantErr: true},
+		{name: "too many pictures", files: manyFiles(maxZipImages+1, picture.Bytes()), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &bytes.Buffer{}
+			writer := zip.NewWriter(archive)
+			for name, data := range tt.files {
+				entry, err := writer.Create(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := entry.Write(data); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			library := &fakeLibraryRepository{word: &models.Library{ID: 1}}
+			images := &fakeImageRepository{}
+			ls := &libraryInteractor{LibraryRepository: library, ImageRepository: images}
+
+			count, err := ls.ImportImagesZIP(context.Background(), bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+			if (err != nil) != tt.wantErr || count != tt.saved || images.saved != tt.saved || len(library.images) != tt.saved {
+				t.Errorf("ImportImagesZIP() = %d, %v, saved %d, attached %d, want %d, error %v", count, err, images.saved, len(library.images), tt.saved, tt.wantErr)
+			}
+		})
+	}
+}
+
+func manyFiles(count int, data []byte) map[string][]byte {
+	files := make(map[string][]byte, count)
+	for i := 1; i <= count; i++ {
+		files[strconv.Itoa(i)+".png"] = data
+	}
+
+	return files
+}
@@ -0,0 +1,283 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/mappers"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/morphology"
+	"strconv"
+	"strings"
+
+	"github.com/tealeg/xlsx"
+)
+
+// containingLemmasLimit caps the words containing the text that a lookup
+// translates when nothing matches it exactly.
+const containingLemmasLimit = 20
+
+// SyncLemmas brings the lemmas in line with the library on start, for words
+// written before their lemmas were saved with them.
+func (ls *libraryInteractor) SyncLemmas(ctx context.Context) (int, error) {
+	return ls.LemmaRepository.SyncLibraryLemmas(ctx)
+}
+
+// Translate looks the text up in the source language of the pair and then in
+// the target one, exact matches first, then inflected forms, then words
+// containing it, and translates into the other one.
+func (ls *libraryInteractor) Translate(ctx context.Context, text string, pair models.LanguagePair) (*models.TranslationLookup, error) {
+	if err := validateLanguagePair(pair); err != nil {
+		return nil, err
+	}
+
+	text, detected := detectLanguages(strings.TrimSpace(text))
+	directions := pairDirections(detected, pair)
+	if len(directions) == 0 {
+		return nil, unsupportedLanguage(text, detected, pair)
+	}
+
+	for _, direction := range directions {
+		lemmas := ls.LemmaRepository.SearchLemmas(direction.Source, text, false, 0)
+		if lookup, err := ls.lookupTranslations(ctx, lemmas, direction, ""); err != nil || lookup != nil {
+			return lookup, err
+		}
+	}
+
+	for _, direction := range directions {
+		lemmas, form := ls.morphLemmas(direction.Source, text)
+		if lookup, err := ls.lookupTranslations(ctx, lemmas, direction, form); err != nil || lookup != nil {
+			return lookup, err
+		}
+	}
+
+	for _, direction := range directions {
+		lemmas := ls.LemmaRepository.SearchLemmas(direction.Source, text, true, containingLemmasLimit)
+		if lookup, err := ls.lookupTranslations(ctx, lemmas, direction, ""); err != nil || lookup != nil {
+			return lookup, err
+		}
+	}
+
+	return &models.TranslationLookup{Translations: []*models.Translation{}}, nil
+}
+
+// lookupTranslations returns nil when the lemmas have no translations.
+func (ls *libraryInteractor) lookupTranslations(ctx context.Context, lemmas []*models.Lemma, direction models.LanguagePair, form string) (*models.TranslationLookup, error) {
+	translations, err := ls.LemmaRepository.GetTranslations(ctx, lemmas, direction.Target)
+	if err != nil || len(translations) == 0 {
+		return nil, err
+	}
+
+	return &models.TranslationLookup{Translations: translations, Lemma: form}, nil
+}
+
+// morphLemmas finds the lemmas of an inflected form: English ones through its
+// dictionary forms, matched against lemmas and library roots, other languages
+// through the stem. It also returns the form that matched.
+func (ls *libraryInteractor) morphLemmas(language, text string) ([]*models.Lemma, string) {
+	for _, form := range morphology.Lemmatize(language, text) {
+		if lemmas := ls.LemmaRepository.SearchLemmas(language, form, false, 0); len(lemmas) > 0 {
+			return lemmas, form
+		}
+
+		if language != models.LangEnglish {
+			continue
+		}
+
+		if lemmas := ls.LemmaRepository.RootLemmas(form); len(lemmas) > 0 {
+			return lemmas, form
+		}
+	}
+
+	if lemmas := ls.LemmaRepository.StemLemmas(language, text); len(lemmas) > 0 {
+		return lemmas, lemmas[0].Text
+	}
+
+	return nil, ""
+}
+
+// MatchLemma finds the library entry of the text in the language, the
+// dictionary form of an inflected word included. It returns nil when there is
+// none.
+func (ls *libraryInteractor) MatchLemma(language, text string) *models.Lemma {
+	text = strings.TrimSpace(text)
+	if lemmas := ls.LemmaRepository.SearchLemmas(language, text, false, 1); len(lemmas) > 0 {
+		return lemmas[0]
+	}
+
+	if lemmas, _ := ls.morphLemmas(language, text); len(lemmas) > 0 {
+		return lemmas[0]
+	}
+
+	return nil
+}
+
+// LocalizeWords puts the pair into the test words: Russian becomes the prompt
+// in the source language and English the answer in the target one. Words
+// without lemmas in both languages are left out and returned apart, so the
+// page can say what was dropped. Custom words are in the pair already and are
+// kept as they are.
+func (ls *libraryInteractor) LocalizeWords(words []*models.Word, pair models.LanguagePair) ([]*models.Word, []*models.Word) {
+	if pair.IsDefault() {
+		return words, nil
+	}
+
+	var untranslated []*models.Word
+
+	localized := make([]*models.Word, 0, len(words))
+	for _, word := range words {
+		if word.CustomID > 0 {
+			localized = append(localized, word)
+			continue
+		}
+
+		lemmas := ls.LemmaRepository.ConceptLemmas(word.ID)
+		if len(lemmas[pair.Source]) == 0 || len(lemmas[pair.Target]) == 0 {
+			untranslated = append(untranslated, word)
+			continue
+		}
+
+		item := *word
+		item.Russian = lemmas[pair.Source][0]
+		item.English = lemmas[pair.Target][0]
+		if pair.Source != models.LangRussian {
+			item.RussianStressed = ""
+		}
+
+		if pair.Target != models.LangEnglish {
+			item.Transcription = ""
+			item.Forms = ""
+		}
+
+		localized = append(localized, &item)
+	}
+
+	return localized, untranslated
+}
+
+func (ls *libraryInteractor) GetWordLemmas(ctx context.Context, wordID string) ([]*models.Lemma, error) {
+	id, err := strconv.Atoi(wordID)
+	if err != nil {
+		return nil, apperrors.LemmaErr.AppendMessage("wrong word id", wordID)
+	}
+
+	return ls.LemmaRepository.GetLemmasByLibraryID(ctx, id)
+}
+
+func (ls *libraryInteractor) CountLemmas(ctx context.Context) (map[string]int64, error) {
+	return ls.LemmaRepository.CountLemmas(ctx)
+}
+
+func (ls *libraryInteractor) AddLemma(ctx context.Context, req *requests.LemmaRequest) error {
+	wordID, err := strconv.Atoi(req.WordID)
+	if err != nil {
+		return apperrors.LemmaErr.AppendMessage("wrong word id", req.WordID)
+	}
+
+	lemma := &models.Lemma{
+		LibraryID: wordID,
+		Language:  strings.ToLower(strings.TrimSpace(req.Language)),
+		Text:      capitalizeFirstRune(strings.TrimSpace(req.Text)),
+	}
+
+	if err := ls.validateLemma(ctx, lemma); err != nil {
+		return err
+	}
+
+	return ls.LemmaRepository.AddLemma(ctx, lemma)
+}
+
+// AddRussianAnswer accepts russian as an answer for the word. The Russian
+// lemma of a library word mirrors its column, so the answer is kept as a
+// lemma of its own linked to the word.
+func (ls *libraryInteractor) AddRussianAnswer(ctx context.Context, wordID int, russian string) error {
+	russian = capitalizeFirstRune(strings.TrimSpace(russian))
+	if russian == "" {
+		return apperrors.LemmaErr.AppendMessage("answer is empty")
+	}
+
+	if _, err := ls.LibraryRepository.GetWordByID(ctx, wordID); err != nil {
+		return apperrors.LemmaErr.AppendMessage("there is no word", wordID)
+	}
+
+	for _, text := range ls.LemmaRepository.ConceptLemmas(wordID)[models.LangRussian] {
+		if strings.EqualFold(text, russian) {
+			return nil
+		}
+	}
+
+	return ls.LemmaRepository.AddLinkedLemma(ctx, wordID, &models.Lemma{Language: models.LangRussian, Text: russian})
+}
+
+func (ls *libraryInteractor) DeleteLemma(ctx context.Context, id string) error {
+	lemmaID, err := strconv.Atoi(id)
+	if err != nil {
+		return apperrors.LemmaErr.AppendMessage("wrong lemma id", id)
+	}
+
+	return ls.LemmaRepository.DeleteLemma(ctx, lemmaID)
+}
+
+// importLemmas adds the lemmas sheet. English and Russian come from the
+// library columns and can't be added this way.
+func (ls *libraryInteractor) importLemmas(ctx context.Context, fileXLS *xlsx.File) error {
+	lemmas, err := mappers.MapXLStoLemmas(fileXLS)
+	if err != nil {
+		return err
+	}
+
+	for _, lemma := range lemmas {
+		lemma.Text = capitalizeFirstRune(lemma.Text)
+		if err := ls.validateLemma(ctx, lemma); err != nil {
+			return err
+		}
+
+		if err := ls.LemmaRepository.AddLemma(ctx, lemma); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ls *libraryInteractor) validateLemma(ctx context.Context, lemma *models.Lemma) error {
+	if lemma.Text == "" {
+		return apperrors.LemmaErr.AppendMessage("empty text for word", lemma.LibraryID)
+	}
+
+	if !models.IsLanguage(lemma.Language) {
+		return apperrors.LemmaErr.AppendMessage("unknown language", lemma.Language)
+	}
+
+	if lemma.Language == models.LangEnglish || lemma.Language == models.LangRussian {
+		return apperrors.LemmaErr.AppendMessage("english and russian are edited in the library, word", lemma.LibraryID)
+	}
+
+	if _, err := ls.LibraryRepository.GetWordByID(ctx, lemma.LibraryID); err != nil {
+		return apperrors.LemmaErr.AppendMessage("there is no word", lemma.LibraryID)
+	}
+
+	return nil
+}
+
+func validateLanguagePair(pair models.LanguagePair) error {
+	if !models.IsLanguage(pair.Source) || !models.IsLanguage(pair.Target) {
+		return apperrors.LanguagePairErr.AppendMessage("unknown language", pair.Source, pair.Target)
+	}
+
+	if pair.Source == pair.Target {
+		return apperrors.LanguagePairErr.AppendMessage("source and target are the same", pair.Source)
+	}
+
+	return nil
+}
+
+// refreshWordsMaps rebuilds the in-memory maps after library words changed.
+// The repository writes the lemmas of a word together with the word.
+func (ls *libraryInteractor) refreshWordsMaps() error {
+	if err := ls.LibraryRepository.UpdateWordsMap(); err != nil {
+		return err
+	}
+
+	return ls.LemmaRepository.UpdateLemmasMap()
+}
@@ -0,0 +1,166 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/domain/models"
+	"server/internal/domain/responses"
+	"server/internal/usercase/search"
+	"sort"
+	"strings"
+)
+
+const didYouMeanLimit = 5
+
+// Suggest returns up to limit library words starting with or containing the
+// text in either language of the pair, with their translation and a score
+// from 1 for an exact match down to 0 for a barely matching substring. Text
+// without letters, a number for one, gets no suggestions.
+func (ls *libraryInteractor) Suggest(ctx context.Context, text string, pair models.LanguagePair, limit int) ([]*responses.Suggestion, error) {
+	if err := validateLanguagePair(pair); err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(text)
+	if !hasLetter(text) {
+		return []*responses.Suggestion{}, nil
+	}
+
+	text, detected := detectLanguages(text)
+	directions := pairDirections(detected, pair)
+	if len(directions) == 0 {
+		return nil, unsupportedLanguage(text, detected, pair)
+	}
+
+	type found struct {
+		lemma       *models.Lemma
+		direction   models.LanguagePair
+		translation string
+		score       float64
+	}
+
+	matches := []*found{}
+	libraryIDs := []int{}
+	for _, direction := range directions {
+		for _, lemma := range ls.translatedLemmas(text, direction, limit) {
+			translation := ls.LemmaRepository.ConceptLemmas(lemma.LibraryID)[direction.Target][0]
+			matches = append(matches, &found{lemma: lemma, direction: direction, translation: translation, score: matchScore(text, lemma.Text)})
+			libraryIDs = append(libraryIDs, lemma.LibraryID)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	words, err := ls.LibraryRepository.GetWordsByIDs(ctx, libraryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	themes := make(map[int]string, len(words))
+	for _, word := range words {
+		themes[word.ID] = word.Theme
+	}
+
+	suggestions := []*responses.Suggestion{}
+	for _, match := range matches {
+		if len(suggestions) == limit {
+			break
+		}
+
+		suggestions = append(suggestions, &responses.Suggestion{
+			Word:        match.lemma.Text,
+			Translation: match.translation,
+			Language:    match.direction.Source,
+			Theme:       themes[match.lemma.LibraryID],
+			Score:       match.score,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// translatedLemmas returns up to limit lemmas matching the text in the source
+// language of the direction that have a translation into the target one. The
+// lemmas without one are dropped before the limit, so the index is asked for
+// more until enough are left or it has no more. Limit below one means no
+// limit.
+func (ls *libraryInteractor) translatedLemmas(text string, direction models.LanguagePair, limit int) []*models.Lemma {
+	for fetch := limit * 2; ; fetch *= 2 {
+		lemmas := ls.LemmaRepository.SearchLemmas(direction.Source, text, true, fetch)
+		translated := make([]*models.Lemma, 0, limit)
+		for _, lemma := range lemmas {
+			if len(ls.LemmaRepository.ConceptLemmas(lemma.LibraryID)[direction.Target]) == 0 {
+				continue
+			}
+
+			translated = append(translated, lemma)
+			if len(translated) == limit {
+				return translated
+			}
+		}
+
+		if fetch < 1 || len(lemmas) < fetch {
+			return translated
+		}
+	}
+}
+
+// DidYouMean returns the words of the pair closest to a text that has no
+// translation, for the user to pick the one they meant.
+func (ls *libraryInteractor) DidYouMean(ctx context.Context, text string, pair models.LanguagePair) ([]string, error) {
+	if err := validateLanguagePair(pair); err != nil {
+		return nil, err
+	}
+
+	text, detected := detectLanguages(strings.TrimSpace(text))
+	directions := pairDirections(detected, pair)
+	if len(directions) == 0 {
+		return nil, unsupportedLanguage(text, detected, pair)
+	}
+
+	words := []string{}
+	seen := make(map[string]bool)
+	for _, direction := range directions {
+		for _, lemma := range ls.LemmaRepository.FuzzyLemmas(direction.Source, text, editBudget(text), didYouMeanLimit) {
+			if key := search.Normalize(lemma.Text); !seen[key] && len(words) < didYouMeanLimit {
+				seen[key] = true
+				words = append(words, lemma.Text)
+			}
+		}
+	}
+
+	return words, nil
+}
+
+// editBudget allows one typo in short words and more in longer ones.
+func editBudget(text string) int {
+	switch length := len([]rune(strings.TrimSpace(text))); {
+	case length <= 4:
+		return 1
+	case length <= 8:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// matchScore ranks like the search index does: exact matches score 1, words
+// starting with the text score above any word only containing it, and the
+// larger part of the word the text covers the higher it scores.
+func matchScore(text, word string) float64 {
+	text, word = search.Normalize(text), search.Normalize(word)
+	if len(word) == 0 {
+		return 0
+	}
+
+	coverage := float64(len([]rune(text))) / float64(len([]rune(word)))
+	switch {
+	case text == word:
+		return 1
+	case strings.HasPrefix(word, text):
+		return 0.5 + coverage/2
+	case strings.Contains(word, text):
+		return coverage / 2
+	}
+
+	return 0
+}
@@ -0,0 +1,333 @@
+package interactor
+
+import (
+	"context"
+	"regexp"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var topicSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GetTopicTree returns topics in display order, children right after their
+// parent with Depth set.
+func (ls *libraryInteractor) GetTopicTree(ctx context.Context) ([]*models.Topic, error) {
+	topics, err := ls.TopicRepository.GetAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.Topic, len(topics))
+	for _, topic := range topics {
+		topic.Children = nil
+		byID[topic.ID] = topic
+	}
+
+	roots := []*models.Topic{}
+	for _, topic := range topics {
+		parent, ok := byID[topic.ParentID]
+		if !ok || parent == topic {
+			roots = append(roots, topic)
+			continue
+		}
+
+		parent.Children = append(parent.Children, topic)
+	}
+
+	tree := make([]*models.Topic, 0, len(topics))
+	visited := make(map[int]bool, len(topics))
+	var walk func(topics []*models.Topic, depth int)
+	walk = func(topics []*models.Topic, depth int) {
+		for _, topic := range topics {
+			if visited[topic.ID] {
+				continue
+			}
+
+			visited[topic.ID] = true
+			topic.Depth = depth
+			tree = append(tree, topic)
+			walk(topic.Children, depth+1)
+		}
+	}
+	walk(roots, 0)
+
+	return tree, nil
+}
+
+// GetTopicWithSubtopics returns the topic by slug and the IDs of it and every
+// nested topic.
+func (ls *libraryInteractor) GetTopicWithSubtopics(ctx context.Context, slug string) (*models.Topic, []int, error) {
+	topic, err := ls.TopicRepository.GetTopicBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree, err := ls.GetTopicTree(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, node := range tree {
+		if node.ID == topic.ID {
+			topic = node
+		}
+	}
+
+	ids := []int{}
+	var collect func(topic *models.Topic)
+	collect = func(topic *models.Topic) {
+		ids = append(ids, topic.ID)
+		for _, child := range topic.Children {
+			collect(child)
+		}
+	}
+	collect(topic)
+
+	return topic, ids, nil
+}
+
+func (ls *libraryInteractor) CreateTopic(ctx context.Context, req *requests.TopicRequest) error {
+	topic, err := ls.topicFromRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return ls.TopicRepository.CreateTopic(ctx, topic)
+}
+
+func (ls *libraryInteractor) UpdateTopic(ctx context.Context, req *requests.TopicRequest) error {
+	topic, err := ls.topicFromRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err := ls.TopicRepository.UpdateTopic(ctx, topic); err != nil {
+		return err
+	}
+
+	return ls.LibraryRepository.UpdateWordsMap()
+}
+
+func (ls *libraryInteractor) DeleteTopic(ctx context.Context, id string) error {
+	topicID, err := strconv.Atoi(id)
+	if err != nil {
+		return apperrors.TopicErr.AppendMessage(err)
+	}
+
+	count, err := ls.TopicRepository.CountTopicWords(ctx, topicID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return apperrors.TopicErr.AppendMessage("topic still has words", count)
+	}
+
+	return ls.TopicRepository.DeleteTopic(ctx, topicID)
+}
+
+// SyncTopics creates topics for themes that don't have one and links the
+// library rows to them. It is run on start so old databases get topics too.
+func (ls *libraryInteractor) SyncTopics(ctx context.Context) (int, error) {
+	themes, err := ls.GetAllTopics()
+	if err != nil {
+		return 0, apperrors.SyncTopicsErr.AppendMessage(err)
+	}
+
+	before, err := ls.TopicRepository.GetAllTopics(ctx)
+	if err != nil {
+		return 0, apperrors.SyncTopicsErr.AppendMessage(err)
+	}
+
+	words := make([]*models.Library, 0, len(themes))
+	for _, theme := range themes {
+		words = append(words, &models.Library{Theme: theme})
+	}
+
+	if err := ls.resolveTopics(ctx, words); err != nil {
+		return 0, apperrors.SyncTopicsErr.AppendMessage(err)
+	}
+
+	for _, word := range words {
+		if err := ls.TopicRepository.SetThemeTopic(ctx, word.Theme, word.TopicID); err != nil {
+			return 0, err
+		}
+	}
+
+	after, err := ls.TopicRepository.GetAllTopics(ctx)
+	if err != nil {
+		return 0, apperrors.SyncTopicsErr.AppendMessage(err)
+	}
+
+	return len(after) - len(before), nil
+}
+
+// resolveTopics turns Theme into TopicID, creating topics that don't exist yet.
+func (ls *libraryInteractor) resolveTopics(ctx context.Context, words []*models.Library) error {
+	topics, err := ls.TopicRepository.GetAllTopics(ctx)
+	if err != nil {
+		return err
+	}
+
+	topicIDs := make(map[string]int, len(topics))
+	slugs := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		topicIDs[strings.ToLower(topic.TitleEn)] = topic.ID
+		slugs[topic.Slug] = true
+	}
+
+	for _, word := range words {
+		theme := strings.TrimSpace(word.Theme)
+		if theme == "" {
+			word.TopicID = 0
+			continue
+		}
+
+		topicID, ok := topicIDs[strings.ToLower(theme)]
+		if !ok {
+			topic := &models.Topic{TitleEn: theme, Slug: uniqueSlug(slugify(theme), slugs)}
+			if err := ls.TopicRepository.CreateTopic(ctx, topic); err != nil {
+				return err
+			}
+
+			topicID = topic.ID
+			topicIDs[strings.ToLower(theme)] = topicID
+			slugs[topic.Slug] = true
+		}
+
+		word.TopicID = topicID
+	}
+
+	return nil
+}
+
+func (ls *libraryInteractor) topicFromRequest(ctx context.Context, req *requests.TopicRequest) (*models.Topic, error) {
+	topic := &models.Topic{
+		Slug:          strings.TrimSpace(req.Slug),
+		TitleEn:       strings.TrimSpace(req.TitleEn),
+		TitleRu:       strings.TrimSpace(req.TitleRu),
+		DescriptionEn: strings.TrimSpace(req.DescriptionEn),
+		DescriptionRu: strings.TrimSpace(req.DescriptionRu),
+		Level:         strings.ToUpper(strings.TrimSpace(req.Level)),
+	}
+
+	var err error
+	if req.ID != "" {
+		if topic.ID, err = strconv.Atoi(req.ID); err != nil {
+			return nil, apperrors.TopicErr.AppendMessage(err)
+		}
+	}
+
+	if req.ParentID != "" {
+		if topic.ParentID, err = strconv.Atoi(req.ParentID); err != nil {
+			return nil, apperrors.TopicErr.AppendMessage(err)
+		}
+	}
+
+	if req.SortOrder != "" {
+		if topic.SortOrder, err = strconv.Atoi(req.SortOrder); err != nil {
+			return nil, apperrors.TopicErr.AppendMessage(err)
+		}
+	}
+
+	if topic.TitleEn == "" {
+		return nil, apperrors.TopicErr.AppendMessage("english title is empty")
+	}
+
+	if topic.Level != "" && !models.IsCEFRLevel(topic.Level) {
+		return nil, apperrors.TopicErr.AppendMessage("unknown CEFR level", topic.Level)
+	}
+
+	topics, err := ls.TopicRepository.GetAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slugs := make(map[string]bool, len(topics))
+	parents := make(map[int]int, len(topics))
+	for _, existing := range topics {
+		parents[existing.ID] = existing.ParentID
+		if existing.ID == topic.ID {
+			continue
+		}
+
+		slugs[existing.Slug] = true
+		if strings.EqualFold(existing.TitleEn, topic.TitleEn) {
+			return nil, apperrors.TopicErr.AppendMessage("topic already exists", topic.TitleEn)
+		}
+	}
+
+	if topic.Slug == "" {
+		topic.Slug = uniqueSlug(slugify(topic.TitleEn), slugs)
+	}
+
+	if !topicSlugPattern.MatchString(topic.Slug) {
+		return nil, apperrors.TopicErr.AppendMessage("slug may contain only a-z, 0-9 and dashes", topic.Slug)
+	}
+
+	if slugs[topic.Slug] {
+		return nil, apperrors.TopicErr.AppendMessage("slug is already used", topic.Slug)
+	}
+
+	if topic.ParentID != 0 {
+		if _, ok := parents[topic.ParentID]; !ok {
+			return nil, apperrors.TopicErr.AppendMessage("there is no parent topic", topic.ParentID)
+		}
+
+		for parentID, steps := topic.ParentID, 0; parentID != 0 && steps <= len(parents); parentID, steps = parents[parentID], steps+1 {
+			if parentID == topic.ID {
+				return nil, apperrors.TopicErr.AppendMessage("topic can't be nested into itself")
+			}
+		}
+	}
+
+	return topic, nil
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'ё': "e", 'є': "ye",
+	'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h",
+	'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// slugify lowercases the title, transliterates cyrillic and joins the rest with
+// dashes.
+func slugify(title string) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			slug.WriteRune(r)
+			dash = false
+		case cyrillicToLatin[r] != "":
+			slug.WriteString(cyrillicToLatin[r])
+			dash = false
+		case !dash && slug.Len() > 0:
+			slug.WriteRune('-')
+			dash = true
+		}
+	}
+
+	result := strings.TrimSuffix(slug.String(), "-")
+	if result == "" {
+		return "topic"
+	}
+
+	return result
+}
+
+func uniqueSlug(slug string, used map[string]bool) string {
+	candidate := slug
+	for i := 2; used[candidate]; i++ {
+		candidate = slug + "-" + strconv.Itoa(i)
+	}
+
+	return candidate
+}
@@ -0,0 +1,157 @@
+package interactor
+
+import (
+	"context"
+	"server/internal/apperrors"
+	"server/internal/domain/models"
+	"server/internal/domain/requests"
+	"server/internal/usercase/repository"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	missingWordsLimit    = 100
+	missingWordMaxLength = 64
+)
+
+type missingWordInteractor struct {
+	MissingWordRepository repository.MissingWordRepository
+	LibraryInteractor     LibraryInteractor
+}
+
+type MissingWordInteractor interface {
+	RecordMissingWord(ctx context.Context, text string, pair models.LanguagePair) error
+	RecordMissingGloss(ctx context.Context, text string, gloss []*models.GlossToken, pair models.LanguagePair) error
+	GetMissingWords(ctx context.Context) ([]*models.MissingWord, error)
+	PublishMissingWord(ctx context.Context, req *requests.MissingWordRequest) error
+	DismissMissingWord(ctx context.Context, id string) error
+}
+
+func NewMissingWordInteractor(m repository.MissingWordRepository, li LibraryInteractor) MissingWordInteractor {
+	return &missingWordInteractor{MissingWordRepository: m, LibraryInteractor: li}
+}
+
+// RecordMissingWord counts a lookup the translate page found nothing for.
+// The text is lower-cased, stripped of surrounding punctuation and repaired
+// the way the lookup does, so spellings of one word share a row.
+func (ms *missingWordInteractor) RecordMissingWord(ctx context.Context, text string, pair models.LanguagePair) error {
+	text = normalizeMissingWord(text)
+	if text == "" || utf8.RuneCountInString(text) > missingWordMaxLength {
+		return nil
+	}
+
+	text, detected := detectLanguages(text)
+	directions := pairDirections(detected, pair)
+	if len(directions) == 0 {
+		return nil
+	}
+
+	language := directions[0].Source
+	if ms.LibraryInteractor.MatchLemma(language, text) != nil {
+		return nil
+	}
+
+	return ms.MissingWordRepository.CountMissingWord(ctx, &models.MissingWord{Text: text, Language: language})
+}
+
+// RecordMissingGloss counts the words of a glossed sentence the library has
+// no entry for, or the whole sentence when none of its words was found.
+func (ms *missingWordInteractor) RecordMissingGloss(ctx context.Context, text string, gloss []*models.GlossToken, pair models.LanguagePair) error {
+	missing := []string{}
+	for _, token := range gloss {
+		if token.Lemma == "" {
+			missing = append(missing, token.Text)
+		}
+	}
+
+	if len(missing) == len(gloss) {
+		return ms.RecordMissingWord(ctx, text, pair)
+	}
+
+	for _, word := range missing {
+		if err := ms.RecordMissingWord(ctx, word, pair); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMissingWords returns the queue, the most looked up words first. The
+// repository leaves out words added to the library since they were looked
+// up, this also leaves out the ones that turned out to be inflected forms.
+func (ms *missingWordInteractor) GetMissingWords(ctx context.Context) ([]*models.MissingWord, error) {
+	words, err := ms.MissingWordRepository.GetMissingWords(ctx, missingWordsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []*models.MissingWord{}
+	for _, word := range words {
+		if ms.LibraryInteractor.MatchLemma(word.Language, word.Text) == nil {
+			missing = append(missing, word)
+		}
+	}
+
+	return missing, nil
+}
+
+// PublishMissingWord adds the word to the library and takes it off the
+// queue. Words of languages other than English and Russian become a lemma of
+// the new library entry.
+func (ms *missingWordInteractor) PublishMissingWord(ctx context.Context, req *requests.MissingWordRequest) error {
+	word, err := ms.missingWord(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	library, err := ms.LibraryInteractor.CreateLibraryWord(ctx, &requests.LibraryWordRequest{
+		English: req.English,
+		Russian: req.Russian,
+		Theme:   req.Theme,
+	})
+	if err != nil {
+		return err
+	}
+
+	if word.Language != models.LangEnglish && word.Language != models.LangRussian {
+		err := ms.LibraryInteractor.AddLemma(ctx, &requests.LemmaRequest{
+			WordID:   strconv.Itoa(library.ID),
+			Language: word.Language,
+			Text:     word.Text,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return ms.MissingWordRepository.DeleteMissingWord(ctx, word.ID)
+}
+
+func (ms *missingWordInteractor) DismissMissingWord(ctx context.Context, id string) error {
+	word, err := ms.missingWord(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return ms.MissingWordRepository.DeleteMissingWord(ctx, word.ID)
+}
+
+func (ms *missingWordInteractor) missingWord(ctx context.Context, id string) (*models.MissingWord, error) {
+	wordID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, apperrors.PublishMissingWordErr.AppendMessage("wrong missing word id", id)
+	}
+
+	return ms.MissingWordRepository.GetMissingWord(ctx, wordID)
+}
+
+func normalizeMissingWord(text string) string {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	return strings.TrimFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,73 @@
+package interactor
+
+import (
+	"context"
+	"reflect"
+	"server/internal/domain/models"
+	"server/internal/usercase/repository"
+	"testing"
+)
+
+type fakeMissingWordRepository struct {
+	repository.MissingWordRepository
+	counted []string
+}
+
+func (f *fakeMissingWordRepository) CountMissingWord(ctx context.Context, word *models.MissingWord) error {
+	f.counted = append(f.counted, word.Language+":"+word.Text)
+	return nil
+}
+
+type fakeLemmaMatcher struct {
+	LibraryInteractor
+}
+
+func (f *fakeLemmaMatcher) MatchLemma(language, text string) *models.Lemma {
+	return nil
+}
+
+func TestRecordMissingGloss(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		gloss []*models.GlossToken
+		want  []string
+	}{
+		{
+			name: "unmatched words",
+			text: "the cat purrs",
+			gloss: []*models.GlossToken{
+				{Text: "the", Lemma: "The"},
+				{Text: "cat", Lemma: "Cat"},
+				{Text: "purrs"},
+			},
+			want: []string{"en:purrs"},
+		},
+		{
+			name:  "nothing matched",
+			text:  "Zorp blick!",
+			gloss: []*models.GlossToken{{Text: "zorp"}, {Text: "blick"}},
+			want:  []string{"en:zorp blick"},
+		},
+		{
+			name:  "everything matched",
+			text:  "big cat",
+			gloss: []*models.GlossToken{{Text: "big", Lemma: "Big"}, {Text: "cat", Lemma: "Cat"}},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing := &fakeMissingWordRepository{counted: []string{}}
+			ms := NewMissingWordInteractor(missing, &fakeLemmaMatcher{})
+			if err := ms.RecordMissingGloss(context.Background(), tt.text, tt.gloss, models.DefaultLanguagePair); err != nil {
+				t.Fatalf("RecordMissingGloss() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(missing.counted, tt.want) {
+				t.Errorf("RecordMissingGloss() counted %q, want %q", missing.counted, tt.want)
+			}
+		})
+	}
+}
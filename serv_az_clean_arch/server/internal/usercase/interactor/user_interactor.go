@@ -29,8 +29,11 @@ type UserInteractor interface {
 	RestoreUserPassword(ctx context.Context, email string) error
 	UpdateUserById(ctx context.Context, user *models.User, userReq *requests.CreateUserRequest) error
 	UpdateUserPasswordById(ctx context.Context, user *models.User, oldPass, newPass, newPassSec string) error
-	GetWordsByUserIdAndLimitAndTopic(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest, topic string) ([]*models.Word, error)
+	UpdateUserLevel(ctx context.Context, userID, level string) error
+	UpdateUserLanguages(ctx context.Context, userID string, pair models.LanguagePair) error
+	GetWordsByUserIdAndLimitAndTopic(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest, topicIDs []int) ([]*models.Word, error)
 	GetWordsByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error)
+	GetPictureWordsByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error)
 	GetLearnByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error)
 	GetUserById(ctx context.Context, id string) (*models.User, error)
 	MoveWordToLearned(ctx context.Context, userID, wordID string) error
@@ -134,6 +137,27 @@ func (us *userInteractor) UpdateUserById(ctx context.Context, user *models.User,
 	return us.UserRepository.UpdateUserById(ctx, userReq)
 }
 
+// UpdateUserLevel sets the course level the new words are picked for. An empty
+// level means no course.
+func (us *userInteractor) UpdateUserLevel(ctx context.Context, userID, level string) error {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	if level != "" && !models.IsCEFRLevel(level) {
+		appErr := apperrors.CourseLevelErr.AppendMessage("unknown CEFR level", level)
+		return appErr
+	}
+
+	return us.UserRepository.UpdateUserLevel(ctx, userID, level)
+}
+
+// UpdateUserLanguages sets the pair the user's tests are built for.
+func (us *userInteractor) UpdateUserLanguages(ctx context.Context, userID string, pair models.LanguagePair) error {
+	if err := validateLanguagePair(pair); err != nil {
+		return err
+	}
+
+	return us.UserRepository.UpdateUserLanguages(ctx, userID, pair)
+}
+
 func (us *userInteractor) UpdateUserPasswordById(ctx context.Context, user *models.User, oldPass, newPass, newPassSec string) error {
 	if !checkPasswordHash(oldPass, user.Password) {
 		appErr := apperrors.UpdateUserPasswordByIdErr.AppendMessage("WRONG Password")
@@ -153,7 +177,7 @@ func (us *userInteractor) UpdateUserPasswordById(ctx context.Context, user *mode
 	return us.UserRepository.UpdateUserPasswordById(ctx, user.ID.String(), hashPass)
 }
 
-func (us *userInteractor) GetWordsByUserIdAndLimitAndTopic(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest, topic string) ([]*models.Word, error) {
+func (us *userInteractor) GetWordsByUserIdAndLimitAndTopic(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest, topicIDs []int) ([]*models.Word, error) {
 	quantity, err := strconv.Atoi(getWordsReq.Limit)
 	if err != nil {
 		appErr := apperrors.GetWordsByUserIdAndLimitAndTopicErr.AppendMessage(err)
@@ -166,7 +190,7 @@ func (us *userInteractor) GetWordsByUserIdAndLimitAndTopic(ctx context.Context,
 		return nil, appErr
 	}
 
-	return us.UserRepository.GetWordsByUserIdAndLimitAndTopic(ctx, &userId, quantity, topic)
+	return us.UserRepository.GetWordsByUserIdAndLimitAndTopic(ctx, &userId, quantity, topicIDs)
 }
 
 func (us *userInteractor) GetWordsByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error) {
@@ -185,6 +209,22 @@ func (us *userInteractor) GetWordsByUsIdAndLimit(ctx context.Context, getWordsRe
 	return us.UserRepository.GetWordsByIDAndLimit(ctx, &userId, quantity)
 }
 
+func (us *userInteractor) GetPictureWordsByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error) {
+	quantity, err := strconv.Atoi(getWordsReq.Limit)
+	if err != nil {
+		appErr := apperrors.GetWordsByUsIdAndLimitServiceErr.AppendMessage(err)
+		return nil, appErr
+	}
+
+	userId, err := uuid.Parse(getWordsReq.ID)
+	if err != nil {
+		appErr := apperrors.GetWordsByUsIdAndLimitServiceErr.AppendMessage(err)
+		return nil, appErr
+	}
+
+	return us.UserRepository.GetWordsWithImageByIDAndLimit(ctx, &userId, quantity)
+}
+
 func (us *userInteractor) GetLearnByUsIdAndLimit(ctx context.Context, getWordsReq *requests.GetWordsByUsIdAndLimitRequest) ([]*models.Word, error) {
 	quantity, err := strconv.Atoi(getWordsReq.Limit)
 	if err != nil {
@@ -0,0 +1,94 @@
+package morphology
+
+import "strings"
+
+// irregularEnglish maps irregular forms to their dictionary form.
+var irregularEnglish = map[string]string{
+	"am": "be", "is": "be", "are": "be", "was": "be", "were": "be", "been": "be", "being": "be",
+	"has": "have", "had": "have", "does": "do", "did": "do", "done": "do",
+	"went": "go", "gone": "go", "goes": "go", "came": "come", "ran": "run",
+	"ate": "eat", "eaten": "eat", "saw": "see", "seen": "see", "took": "take", "taken": "take",
+	"gave": "give", "given": "give", "made": "make", "said": "say", "got": "get", "gotten": "get",
+	"knew": "know", "known": "know", "thought": "think", "brought": "bring", "bought": "buy",
+	"caught": "catch", "taught": "teach", "found": "find", "told": "tell", "felt": "feel",
+	"left": "leave", "kept": "keep", "slept": "sleep", "met": "meet", "sat": "sit",
+	"stood": "stand", "understood": "understand", "wrote": "write", "written": "write",
+	"spoke": "speak", "spoken": "speak", "broke": "break", "broken": "break",
+	"chose": "choose", "chosen": "choose", "drove": "drive", "driven": "drive",
+	"rode": "ride", "ridden": "ride", "flew": "fly", "flown": "fly", "drew": "draw", "drawn": "draw",
+	"grew": "grow", "grown": "grow", "threw": "throw", "thrown": "throw", "began": "begin",
+	"begun": "begin", "drank": "drink", "drunk": "drink", "sang": "sing", "sung": "sing",
+	"swam": "swim", "swum": "swim", "rang": "ring", "rung": "ring", "sold": "sell", "held": "hold",
+	"lost": "lose", "paid": "pay", "built": "build", "sent": "send", "spent": "spend",
+	"fell": "fall", "fallen": "fall", "forgot": "forget", "forgotten": "forget", "hid": "hide",
+	"hidden": "hide", "bit": "bite", "bitten": "bite", "woke": "wake", "woken": "wake",
+	"wore": "wear", "worn": "wear", "tore": "tear", "torn": "tear", "fought": "fight",
+	"led": "lead", "fed": "feed", "heard": "hear", "meant": "mean", "lay": "lie", "lain": "lie",
+	"rose": "rise", "risen": "rise", "shook": "shake", "shaken": "shake", "stole": "steal",
+	"stolen": "steal", "won": "win", "struck": "strike", "hung": "hang", "dug": "dig",
+	"children": "child", "men": "man", "women": "woman", "feet": "foot", "teeth": "tooth",
+	"mice": "mouse", "geese": "goose", "people": "person", "oxen": "ox", "lice": "louse",
+	"better": "good", "best": "good", "worse": "bad", "worst": "bad", "further": "far", "farther": "far",
+}
+
+type englishRule struct {
+	suffix       string
+	replacements []string
+	undouble     bool
+}
+
+// englishRules are tried in order, every replacement of a matching suffix
+// gives a candidate. Undouble also tries dropping a doubled last consonant,
+// as in "running" and "bigger".
+var englishRules = []englishRule{
+	{suffix: "ies", replacements: []string{"y", "ie"}},
+	{suffix: "ied", replacements: []string{"y", "ie"}},
+	{suffix: "ier", replacements: []string{"y"}},
+	{suffix: "iest", replacements: []string{"y"}},
+	{suffix: "ily", replacements: []string{"y"}},
+	{suffix: "ying", replacements: []string{"ie", "y"}},
+	{suffix: "ves", replacements: []string{"f", "fe"}},
+	{suffix: "es", replacements: []string{"", "e"}},
+	{suffix: "s", replacements: []string{""}},
+	{suffix: "ing", replacements: []string{"", "e"}, undouble: true},
+	{suffix: "ed", replacements: []string{"", "e"}, undouble: true},
+	{suffix: "est", replacements: []string{"", "e"}, undouble: true},
+	{suffix: "er", replacements: []string{"", "e"}, undouble: true},
+	{suffix: "ly", replacements: []string{""}},
+}
+
+func lemmatizeEnglish(word string) []string {
+	candidates := []string{}
+	seen := map[string]bool{word: true}
+	add := func(candidate string) {
+		if len(candidate) > 1 && !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	if lemma, ok := irregularEnglish[word]; ok {
+		add(lemma)
+	}
+
+	for _, rule := range englishRules {
+		if !strings.HasSuffix(word, rule.suffix) || strings.HasSuffix(word, "ss") && rule.suffix == "s" {
+			continue
+		}
+
+		base := strings.TrimSuffix(word, rule.suffix)
+		if rule.undouble && len(base) > 2 && base[len(base)-1] == base[len(base)-2] && !isEnglishVowel(base[len(base)-1]) {
+			add(base[:len(base)-1])
+		}
+
+		for _, replacement := range rule.replacements {
+			add(base + replacement)
+		}
+	}
+
+	return candidates
+}
+
+func isEnglishVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
@@ -0,0 +1,66 @@
+// Package morphology reduces inflected words to the forms kept in the
+// library: English words to their dictionary form, Russian ones to a stem
+// shared by all their forms.
+package morphology
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Lemmatize returns the possible dictionary forms of an inflected word, the
+// most likely first. The word itself is not among them.
+func Lemmatize(language, word string) []string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	switch language {
+	case "en":
+		return lemmatizeEnglish(word)
+	}
+
+	return nil
+}
+
+// Stem returns the stem all forms of the word share, or "" when the language
+// has no stemmer.
+func Stem(language, word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+	switch language {
+	case "ru":
+		return stemRussian(word)
+	}
+
+	return ""
+}
+
+// Tokenize splits text into words, keeping apostrophes and hyphens inside a
+// word, as in "don't" and "well-known".
+func Tokenize(text string) []string {
+	tokens := []string{}
+	runes := []rune(text)
+	start := -1
+	for i, r := range runes {
+		inner := (r == '\'' || r == '’' || r == '-') && start >= 0 && i+1 < len(runes) && isWordRune(runes[i+1])
+		if isWordRune(r) || inner {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			tokens = append(tokens, string(runes[start:i]))
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, string(runes[start:]))
+	}
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
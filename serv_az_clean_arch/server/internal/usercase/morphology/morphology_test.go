@@ -0,0 +1,91 @@
+package morphology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStemRussian(t *testing.T) {
+	tests := []struct {
+		stem  string
+		forms []string
+	}{
+		{stem: "книг", forms: []string{"книга", "книги", "книгу", "книгой", "книгах", "Книге"}},
+		{stem: "красив", forms: []string{"красивый", "красивая", "красивого", "красивыми"}},
+		{stem: "чита", forms: []string{"читать", "читал", "читают", "читающий"}},
+		{stem: "елк", forms: []string{"ёлка", "елка", "ёлки"}},
+		{stem: "важн", forms: []string{"важнейший", "важнейшая"}},
+		{stem: "длин", forms: []string{"длинный", "длинная"}},
+		{stem: "бесконечн", forms: []string{"бесконечность", "бесконечности"}},
+		{stem: "смелост", forms: []string{"смелость"}},
+		{stem: "мыл", forms: []string{"мылся", "мылась"}},
+	}
+
+	for _, tt := range tests {
+		for _, form := range tt.forms {
+			if stem := Stem("ru", form); stem != tt.stem {
+				t.Errorf("Stem(ru, %q) = %q, want %q", form, stem, tt.stem)
+			}
+		}
+	}
+}
+
+func TestStemUnsupportedLanguage(t *testing.T) {
+	if stem := Stem("en", "running"); stem != "" {
+		t.Errorf("Stem(en, running) = %q, want none", stem)
+	}
+}
+
+func TestLemmatizeEnglish(t *testing.T) {
+	tests := []struct {
+		word  string
+		first string
+		all   []string
+	}{
+		{word: "running", first: "run"},
+		{word: "Studies", first: "study"},
+		{word: "went", first: "go"},
+		{word: "children", first: "child"},
+		{word: "bigger", first: "big"},
+		{word: "making", first: "mak", all: []string{"mak", "make"}},
+		{word: "knives", first: "knif", all: []string{"knif", "knife", "kniv", "knive"}},
+		{word: "boxes", first: "box", all: []string{"box", "boxe"}},
+		{word: "glass", all: []string{}},
+	}
+
+	for _, tt := range tests {
+		lemmas := Lemmatize("en", tt.word)
+		if tt.first != "" && (len(lemmas) == 0 || lemmas[0] != tt.first) {
+			t.Errorf("Lemmatize(en, %q) = %v, want %q first", tt.word, lemmas, tt.first)
+		}
+
+		if tt.all != nil && !reflect.DeepEqual(lemmas, tt.all) {
+			t.Errorf("Lemmatize(en, %q) = %v, want %v", tt.word, lemmas, tt.all)
+		}
+	}
+}
+
+func TestLemmatizeUnsupportedLanguage(t *testing.T) {
+	if lemmas := Lemmatize("ru", "книги"); lemmas != nil {
+		t.Errorf("Lemmatize(ru, книги) = %v, want none", lemmas)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text   string
+		tokens []string
+	}{
+		{text: "Don't stop, well-known man!", tokens: []string{"Don't", "stop", "well-known", "man"}},
+		{text: "it’s 2 o'clock", tokens: []string{"it’s", "2", "o'clock"}},
+		{text: "'quoted' - dash", tokens: []string{"quoted", "dash"}},
+		{text: "Привет, мир", tokens: []string{"Привет", "мир"}},
+		{text: " ... ", tokens: []string{}},
+	}
+
+	for _, tt := range tests {
+		if tokens := Tokenize(tt.text); !reflect.DeepEqual(tokens, tt.tokens) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.text, tokens, tt.tokens)
+		}
+	}
+}
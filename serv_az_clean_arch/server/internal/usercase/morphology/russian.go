@@ -0,0 +1,127 @@
+package morphology
+
+import (
+	"sort"
+	"strings"
+)
+
+// The endings of the Snowball Russian stemmer. The "preceded" groups only
+// match after а or я, which stays in the stem.
+var (
+	perfectiveGerundPreceded = []string{"в", "вши", "вшись"}
+	perfectiveGerund         = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+	adjective                = []string{"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею"}
+	participlePreceded = []string{"ем", "нн", "вш", "ющ", "щ"}
+	participle         = []string{"ивш", "ывш", "ующ"}
+	reflexive          = []string{"ся", "сь"}
+	verbPreceded       = []string{"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно"}
+	verb               = []string{"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю"}
+	noun = []string{"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я"}
+	superlative   = []string{"ейш", "ейше"}
+	derivational  = []string{"ост", "ость"}
+	russianVowels = "аеиоуыэюя"
+)
+
+// stemRussian is the Snowball Russian stemmer.
+func stemRussian(word string) string {
+	runes := []rune(strings.ReplaceAll(word, "ё", "е"))
+	rv := len(runes)
+	for i, r := range runes {
+		if isRussianVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+
+	stem, rest := runes[:rv], runes[rv:]
+
+	// step 1
+	if trimmed, ok := trimEnding(rest, perfectiveGerundPreceded, perfectiveGerund); ok {
+		rest = trimmed
+	} else {
+		rest, _ = trimEnding(rest, nil, reflexive)
+		if trimmed, ok := trimEnding(rest, nil, adjective); ok {
+			rest, _ = trimEnding(trimmed, participlePreceded, participle)
+		} else if trimmed, ok := trimEnding(rest, verbPreceded, verb); ok {
+			rest = trimmed
+		} else {
+			rest, _ = trimEnding(rest, nil, noun)
+		}
+	}
+
+	// step 2
+	rest, _ = trimEnding(rest, nil, []string{"и"})
+
+	// step 3, the derivational ending has to be in R2
+	r2 := region(runes, region(runes, 0))
+	if trimmed, ok := trimEnding(rest, nil, derivational); ok && len(stem)+len(trimmed) >= r2 {
+		rest = trimmed
+	}
+
+	// step 4
+	if trimmed, ok := trimEnding(rest, nil, []string{"нн"}); ok {
+		rest = append(trimmed, 'н')
+	} else if trimmed, ok := trimEnding(rest, nil, superlative); ok {
+		rest = trimmed
+		if trimmed, ok := trimEnding(rest, nil, []string{"нн"}); ok {
+			rest = append(trimmed, 'н')
+		}
+	} else {
+		rest, _ = trimEnding(rest, nil, []string{"ь"})
+	}
+
+	return string(stem) + string(rest)
+}
+
+// trimEnding removes the longest ending of either group, the preceded ones
+// only after а or я.
+func trimEnding(word []rune, preceded, plain []string) ([]rune, bool) {
+	type ending struct {
+		text     []rune
+		preceded bool
+	}
+
+	endings := []ending{}
+	for _, e := range preceded {
+		endings = append(endings, ending{text: []rune(e), preceded: true})
+	}
+
+	for _, e := range plain {
+		endings = append(endings, ending{text: []rune(e)})
+	}
+
+	sort.SliceStable(endings, func(i, j int) bool { return len(endings[i].text) > len(endings[j].text) })
+	for _, e := range endings {
+		cut := len(word) - len(e.text)
+		if cut < 0 || string(word[cut:]) != string(e.text) {
+			continue
+		}
+
+		if e.preceded && (cut == 0 || word[cut-1] != 'а' && word[cut-1] != 'я') {
+			continue
+		}
+
+		return word[:cut], true
+	}
+
+	return word, false
+}
+
+// region returns where the region after the first non-vowel following a
+// vowel starts, looking from start.
+func region(word []rune, start int) int {
+	for i := start + 1; i < len(word); i++ {
+		if !isRussianVowel(word[i]) && isRussianVowel(word[i-1]) {
+			return i + 1
+		}
+	}
+
+	return len(word)
+}
+
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune(russianVowels, r)
+}
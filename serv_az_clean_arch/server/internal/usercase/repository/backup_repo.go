@@ -7,6 +7,6 @@ import (
 
 type BackUpCopyRepo interface {
 	GetAllWordsFromBackUpXlsx() ([]*models.Library, error)
-	SaveWordsAsXLSX(words []*models.Library) error
+	SaveWordsAsXLSX(words []*models.Library, phrases []*models.Phrase, lemmas []*models.Lemma) error
 	OpenFile() (*os.File, error)
 }
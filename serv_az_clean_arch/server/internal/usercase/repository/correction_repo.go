@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type CorrectionRepository interface {
+	CreateCorrection(ctx context.Context, correction *models.Correction) error
+	GetCorrectionsByUserID(ctx context.Context, userID string) ([]*models.Correction, error)
+	GetCorrectionsByStatus(ctx context.Context, status string) ([]*models.Correction, error)
+	GetCorrectionByID(ctx context.Context, id int) (*models.Correction, error)
+	UpdateCorrection(ctx context.Context, correction *models.Correction) error
+}
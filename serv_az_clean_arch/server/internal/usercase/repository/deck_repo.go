@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type DeckRepository interface {
+	CreateDeck(ctx context.Context, deck *models.Deck, wordIDs, learnIDs []int) error
+	GetDecksByUserID(ctx context.Context, userID string) ([]*models.Deck, error)
+	GetDeck(ctx context.Context, id int, userID string) (*models.Deck, error)
+	LoadDeckWords(ctx context.Context, deck *models.Deck) error
+	DeleteDeck(ctx context.Context, deck *models.Deck) error
+	GetDeckWords(ctx context.Context, deck *models.Deck, learn bool, limit int) ([]*models.Word, error)
+	AddCustomWord(ctx context.Context, word *models.CustomWord) error
+	DeleteCustomWord(ctx context.Context, id int, userID string) error
+	GetCustomWords(ctx context.Context, deck *models.Deck, learn bool, limit int) ([]*models.CustomWord, error)
+	UpdateCustomWordProgress(ctx context.Context, id int, userID string, learn, learned bool) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type DisputeRepository interface {
+	CreateDispute(ctx context.Context, dispute *models.Dispute) error
+	GetDisputesByUserID(ctx context.Context, userID string) ([]*models.Dispute, error)
+	GetDisputesByStatus(ctx context.Context, status string) ([]*models.Dispute, error)
+	GetDisputeByID(ctx context.Context, id int) (*models.Dispute, error)
+	UpdateDisputeStatus(ctx context.Context, id int, status, note string) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type HistoryRepository interface {
+	AddLookup(ctx context.Context, lookup *models.LookupHistory) error
+	GetUserHistory(ctx context.Context, userID string, limit int) ([]*models.LookupHistory, error)
+	DeleteUserHistory(ctx context.Context, userID string) error
+}
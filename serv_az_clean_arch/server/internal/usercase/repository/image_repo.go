@@ -0,0 +1,5 @@
+package repository
+
+type ImageRepository interface {
+	SaveImage(data []byte, format string) (string, error)
+}
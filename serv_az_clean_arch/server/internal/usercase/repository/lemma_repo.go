@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type LemmaRepository interface {
+	SyncLibraryLemmas(ctx context.Context) (int, error)
+	UpdateLemmasMap() error
+	ConceptLemmas(libraryID int) map[string][]string
+	SearchLemmas(language, text string, like bool, limit int) []*models.Lemma
+	FuzzyLemmas(language, text string, budget, limit int) []*models.Lemma
+	StemLemmas(language, text string) []*models.Lemma
+	RootLemmas(root string) []*models.Lemma
+	GetTranslations(ctx context.Context, from []*models.Lemma, language string) ([]*models.Translation, error)
+	GetLemmasByLibraryID(ctx context.Context, libraryID int) ([]*models.Lemma, error)
+	GetExtraLemmas(ctx context.Context) ([]*models.Lemma, error)
+	CountLemmas(ctx context.Context) (map[string]int64, error)
+	AddLemma(ctx context.Context, lemma *models.Lemma) error
+	AddLinkedLemma(ctx context.Context, libraryID int, lemma *models.Lemma) error
+	DeleteLemma(ctx context.Context, id int) error
+}
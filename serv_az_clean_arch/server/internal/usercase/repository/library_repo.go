@@ -3,20 +3,27 @@ package repository
 import (
 	"context"
 	"server/internal/domain/models"
+	"server/internal/domain/requests"
 )
 
 type LibraryRepository interface {
 	GetAllWords() ([]*models.Library, error)
-	GetTranslationRus(word string) ([]*models.Library, error)
-	GetTranslationRusLike(word string) ([]*models.Library, error)
-	GetTranslationRusLikeWord(word string) (*models.Library, error)
-	GetTranslationEngl(word string) ([]*models.Library, error)
-	GetTranslationEnglLike(word string) ([]*models.Library, error)
-	GetTranslationEnglLikeWord(word string) (*models.Library, error)
 	InsertWordsLibrary(ctx context.Context, library []*models.Library) error
 	InsertWordLibrary(ctx context.Context, word *models.Library) error
-	UpdateWord(ctx context.Context, word *models.Library) error
+	UpdateWord(ctx context.Context, word *models.Library, authorID string) error
 	InitWordsMap() error
 	UpdateWordsMap() error
 	GetAllTopics() ([]string, error)
+	CountWords() (int64, error)
+	IsLibrarySeeded(ctx context.Context) (bool, error)
+	MarkLibrarySeeded(ctx context.Context, seed *models.LibrarySeed) error
+	GetWordsByFilter(ctx context.Context, filter *requests.LibraryFilterRequest) ([]*models.Library, int64, error)
+	GetWordByID(ctx context.Context, id int) (*models.Library, error)
+	GetWordsByIDs(ctx context.Context, ids []int) ([]*models.Library, error)
+	GetMaxID(ctx context.Context) (int, error)
+	DeleteWord(ctx context.Context, id int) error
+	MergeWords(ctx context.Context, canonicalID int, duplicateIDs []int) error
+	GetAllPartsOfSpeech() ([]string, error)
+	SetWordImage(ctx context.Context, id int, image string) error
+	SetWordImages(ctx context.Context, images map[int]string) error
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type MissingWordRepository interface {
+	CountMissingWord(ctx context.Context, word *models.MissingWord) error
+	GetMissingWords(ctx context.Context, limit int) ([]*models.MissingWord, error)
+	GetMissingWord(ctx context.Context, id int) (*models.MissingWord, error)
+	DeleteMissingWord(ctx context.Context, id int) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type PhraseRepository interface {
+	GetAllPhrases(ctx context.Context) ([]*models.Phrase, error)
+	GetPhrasesByWordIDs(ctx context.Context, wordIDs []int) ([]*models.Phrase, error)
+	UpsertPhrase(ctx context.Context, phrase *models.Phrase) error
+}
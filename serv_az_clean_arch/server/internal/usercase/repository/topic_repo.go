@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type TopicRepository interface {
+	GetAllTopics(ctx context.Context) ([]*models.Topic, error)
+	GetTopicByID(ctx context.Context, id int) (*models.Topic, error)
+	GetTopicBySlug(ctx context.Context, slug string) (*models.Topic, error)
+	CreateTopic(ctx context.Context, topic *models.Topic) error
+	UpdateTopic(ctx context.Context, topic *models.Topic) error
+	DeleteTopic(ctx context.Context, id int) error
+	CountTopicWords(ctx context.Context, id int) (int64, error)
+	SetThemeTopic(ctx context.Context, theme string, topicID int) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"server/internal/domain/models"
+)
+
+type TranslationGroupRepository interface {
+	GetAllGroups(ctx context.Context) ([]*models.TranslationGroup, error)
+	GetGroupByID(ctx context.Context, id int) (*models.TranslationGroup, error)
+	GetGroupByName(ctx context.Context, name string) (*models.TranslationGroup, error)
+	CreateGroup(ctx context.Context, group *models.TranslationGroup) error
+	DeleteGroup(ctx context.Context, id int) error
+	SetWordGroup(ctx context.Context, wordID, groupID int) error
+	AddVariant(ctx context.Context, variant *models.TranslationVariant) error
+	DeleteVariant(ctx context.Context, id int) error
+}
@@ -14,13 +14,18 @@ type UserRepository interface {
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	UpdateUserPasswordById(ctx context.Context, userID, newPass string) error
+	UpdateUserLevel(ctx context.Context, userID, level string) error
+	UpdateUserLanguages(ctx context.Context, userID string, pair models.LanguagePair) error
+	UpdateUserKeepHistory(ctx context.Context, userID string, keep bool) error
 	UpdateUserById(ctx context.Context, userReq *requests.CreateUserRequest) error
 	GetWordsByIDAndLimit(ctx context.Context, id *uuid.UUID, limit int) ([]*models.Word, error)
+	GetWordsWithImageByIDAndLimit(ctx context.Context, id *uuid.UUID, limit int) ([]*models.Word, error)
 	GetLearnByIDAndLimit(ctx context.Context, id *uuid.UUID, limit int) ([]*models.Word, error)
 	GetUserById(ctx context.Context, id *uuid.UUID) (*models.User, error)
 	MoveWordToLearned(ctx context.Context, user *models.User, word *models.Word) error
 	AddWordToLearn(ctx context.Context, user *models.User, word *models.Word) error
+	GetLearnedWordIDs(ctx context.Context, id *uuid.UUID) ([]int, error)
 	DeleteLearnWordFromUserByWordID(ctx context.Context, user *models.User, word *models.Word) error
-	GetWordsByUserIdAndLimitAndTopic(ctx context.Context, id *uuid.UUID, limit int, topic string) ([]*models.Word, error)
+	GetWordsByUserIdAndLimitAndTopic(ctx context.Context, id *uuid.UUID, limit int, topicIDs []int) ([]*models.Word, error)
 	GetAllUsers(ctx context.Context) ([]*models.User, error)
 }
@@ -0,0 +1,281 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/agnivade/levenshtein"
+)
+
+type Rank int
+
+const (
+	Exact Rank = iota
+	Prefix
+	Substring
+	Fuzzy
+)
+
+// trigramSize is the length of the grams substrings are looked up by,
+// shorter queries are matched by scanning the language's texts.
+const trigramSize = 3
+
+// Entry is a text to be found, ID is how the caller maps it back.
+type Entry struct {
+	ID       int
+	Language string
+	Text     string
+}
+
+type Match struct {
+	Entry
+	Rank     Rank
+	Distance int
+}
+
+// Index finds entries of a language by exact text, prefix and substring. It
+// is built once and only read afterwards, so it's safe for concurrent use.
+type Index struct {
+	languages map[string]*languageIndex
+}
+
+type languageIndex struct {
+	entries  []Entry
+	keys     []string
+	trie     *trieNode
+	trigrams map[string][]int
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	entries  []int
+}
+
+func NewIndex(entries []Entry) *Index {
+	index := &Index{languages: make(map[string]*languageIndex)}
+	for _, entry := range entries {
+		key := Normalize(entry.Text)
+		if key == "" {
+			continue
+		}
+
+		language, ok := index.languages[entry.Language]
+		if !ok {
+			language = &languageIndex{trie: &trieNode{}, trigrams: make(map[string][]int)}
+			index.languages[entry.Language] = language
+		}
+
+		language.add(entry, key)
+	}
+
+	return index
+}
+
+func (li *languageIndex) add(entry Entry, key string) {
+	i := len(li.entries)
+	li.entries = append(li.entries, entry)
+	li.keys = append(li.keys, key)
+
+	node := li.trie
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+
+			child = &trieNode{}
+			node.children[r] = child
+		}
+
+		node = child
+	}
+	node.entries = append(node.entries, i)
+
+	seen := make(map[string]bool)
+	for _, gram := range trigrams(key) {
+		if !seen[gram] {
+			seen[gram] = true
+			li.trigrams[gram] = append(li.trigrams[gram], i)
+		}
+	}
+}
+
+// Search returns the entries of language matching query, exact matches first,
+// then the ones starting with it, then the ones containing it. Within a rank
+// shorter texts go first. Limit below one means no limit.
+func (index *Index) Search(language, query string, limit int) []*Match {
+	matches := []*Match{}
+	li, ok := index.languages[language]
+	key := Normalize(query)
+	if !ok || key == "" {
+		return matches
+	}
+
+	found := make(map[int]bool)
+	collect := func(indexes []int, rank Rank) bool {
+		sort.Slice(indexes, func(a, b int) bool {
+			ka, kb := li.keys[indexes[a]], li.keys[indexes[b]]
+			if len(ka) != len(kb) {
+				return len(ka) < len(kb)
+			}
+
+			return ka < kb
+		})
+
+		for _, i := range indexes {
+			if found[i] {
+				continue
+			}
+
+			found[i] = true
+			matches = append(matches, &Match{Entry: li.entries[i], Rank: rank})
+			if limit > 0 && len(matches) == limit {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	node := li.find(key)
+	if node != nil {
+		if !collect(append([]int{}, node.entries...), Exact) {
+			return matches
+		}
+
+		if !collect(node.subtree(nil), Prefix) {
+			return matches
+		}
+	}
+
+	collect(li.containing(key), Substring)
+	return matches
+}
+
+// Fuzzy returns the entries of language within the edit distance budget of
+// query, closest first. It's the fallback for misspelled words, so it scans
+// every text of the language that is not too long or short to fit.
+func (index *Index) Fuzzy(language, query string, budget, limit int) []*Match {
+	matches := []*Match{}
+	li, ok := index.languages[language]
+	key := Normalize(query)
+	if !ok || key == "" {
+		return matches
+	}
+
+	length := len([]rune(key))
+	for i, k := range li.keys {
+		diff := len([]rune(k)) - length
+		if diff > budget || -diff > budget {
+			continue
+		}
+
+		if distance := levenshtein.ComputeDistance(key, k); distance <= budget {
+			matches = append(matches, &Match{Entry: li.entries[i], Rank: Fuzzy, Distance: distance})
+		}
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Distance != matches[b].Distance {
+			return matches[a].Distance < matches[b].Distance
+		}
+
+		return Normalize(matches[a].Text) < Normalize(matches[b].Text)
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+func (li *languageIndex) find(key string) *trieNode {
+	node := li.trie
+	for _, r := range key {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+
+	return node
+}
+
+func (node *trieNode) subtree(indexes []int) []int {
+	for _, child := range node.children {
+		indexes = append(indexes, child.entries...)
+		indexes = child.subtree(indexes)
+	}
+
+	return indexes
+}
+
+// containing intersects the posting lists of the key's trigrams and checks
+// the candidates, since grams in a different order match too.
+func (li *languageIndex) containing(key string) []int {
+	grams := trigrams(key)
+	if len(grams) == 0 {
+		indexes := []int{}
+		for i, k := range li.keys {
+			if strings.Contains(k, key) {
+				indexes = append(indexes, i)
+			}
+		}
+
+		return indexes
+	}
+
+	candidates := li.trigrams[grams[0]]
+	for _, gram := range grams[1:] {
+		candidates = intersect(candidates, li.trigrams[gram])
+		if len(candidates) == 0 {
+			break
+		}
+	}
+
+	indexes := []int{}
+	for _, i := range candidates {
+		if strings.Contains(li.keys[i], key) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// Normalize is the form texts are indexed and looked up in.
+func Normalize(text string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(text)), "ё", "е")
+}
+
+func trigrams(key string) []string {
+	runes := []rune(key)
+	grams := []string{}
+	for i := 0; i+trigramSize <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+trigramSize]))
+	}
+
+	return grams
+}
+
+// intersect expects both lists sorted, which posting lists are since entries
+// are added in order.
+func intersect(a, b []int) []int {
+	result := []int{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchRanking(t *testing.T) {
+	index := NewIndex([]Entry{
+		{ID: 1, Language: "en", Text: "scatter"},
+		{ID: 2, Language: "en", Text: "Catalog"},
+		{ID: 3, Language: "en", Text: "cat"},
+		{ID: 4, Language: "en", Text: "cats"},
+		{ID: 5, Language: "en", Text: "dog"},
+		{ID: 6, Language: "ru", Text: "Ёж"},
+		{ID: 7, Language: "ru", Text: "ежевика"},
+		{ID: 8, Language: "ru", Text: "кот"},
+	})
+
+	tests := []struct {
+		name     string
+		language string
+		query    string
+		limit    int
+		ids      []int
+		ranks    []Rank
+	}{
+		{name: "exact, prefix, substring", language: "en", query: "cat", ids: []int{3, 4, 2, 1}, ranks: []Rank{Exact, Prefix, Prefix, Substring}},
+		{name: "case and spaces", language: "en", query: "  CAT ", ids: []int{3, 4, 2, 1}, ranks: []Rank{Exact, Prefix, Prefix, Substring}},
+		{name: "limit", language: "en", query: "cat", limit: 2, ids: []int{3, 4}, ranks: []Rank{Exact, Prefix}},
+		{name: "short substring", language: "en", query: "at", ids: []int{3, 4, 2, 1}, ranks: []Rank{Substring, Substring, Substring, Substring}},
+		{name: "substring only", language: "en", query: "atte", ids: []int{1}, ranks: []Rank{Substring}},
+		{name: "yo is ye", language: "ru", query: "еж", ids: []int{6, 7}, ranks: []Rank{Exact, Prefix}},
+		{name: "other language", language: "ru", query: "cat", ids: []int{}, ranks: []Rank{}},
+		{name: "unknown language", language: "de", query: "cat", ids: []int{}, ranks: []Rank{}},
+		{name: "empty query", language: "en", query: " ", ids: []int{}, ranks: []Rank{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, ranks := []int{}, []Rank{}
+			for _, match := range index.Search(tt.language, tt.query, tt.limit) {
+				ids = append(ids, match.ID)
+				ranks = append(ranks, match.Rank)
+			}
+
+			if !reflect.DeepEqual(ids, tt.ids) || !reflect.DeepEqual(ranks, tt.ranks) {
+				t.Errorf("Search(%q, %q, %d) = %v %v, want %v %v", tt.language, tt.query, tt.limit, ids, ranks, tt.ids, tt.ranks)
+			}
+		})
+	}
+}
+
+func TestFuzzyRanking(t *testing.T) {
+	index := NewIndex([]Entry{
+		{ID: 1, Language: "en", Text: "mouse"},
+		{ID: 2, Language: "en", Text: "house"},
+		{ID: 3, Language: "en", Text: "horse"},
+		{ID: 4, Language: "en", Text: "households"},
+		{ID: 5, Language: "ru", Text: "ёлка"},
+	})
+
+	tests := []struct {
+		name      string
+		language  string
+		query     string
+		budget    int
+		limit     int
+		ids       []int
+		distances []int
+	}{
+		{name: "one typo", language: "en", query: "hause", budget: 1, ids: []int{2}, distances: []int{1}},
+		{name: "closest first, then by text", language: "en", query: "hause", budget: 2, ids: []int{2, 3, 1}, distances: []int{1, 2, 2}},
+		{name: "limit", language: "en", query: "hause", budget: 2, limit: 2, ids: []int{2, 3}, distances: []int{1, 2}},
+		{name: "exact is distance zero", language: "en", query: "House", budget: 1, ids: []int{2, 3, 1}, distances: []int{0, 1, 1}},
+		{name: "yo is ye", language: "ru", query: "елки", budget: 1, ids: []int{5}, distances: []int{1}},
+		{name: "nothing close", language: "en", query: "table", budget: 1, ids: []int{}, distances: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, distances := []int{}, []int{}
+			for _, match := range index.Fuzzy(tt.language, tt.query, tt.budget, tt.limit) {
+				if match.Rank != Fuzzy {
+					t.Errorf("Fuzzy(%q) rank = %v, want %v", tt.query, match.Rank, Fuzzy)
+				}
+
+				ids = append(ids, match.ID)
+				distances = append(distances, match.Distance)
+			}
+
+			if !reflect.DeepEqual(ids, tt.ids) || !reflect.DeepEqual(distances, tt.distances) {
+				t.Errorf("Fuzzy(%q, %q, %d, %d) = %v %v, want %v %v", tt.language, tt.query, tt.budget, tt.limit, ids, distances, tt.ids, tt.distances)
+			}
+		})
+	}
+}